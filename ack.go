@@ -0,0 +1,115 @@
+package gogo
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Delivery is a result from GoAcked, which must be acknowledged with Ack once
+// it has been processed.
+type Delivery[T any] struct {
+	Optional[T]
+	// Attempt is 1 the first time a result is delivered, and goes up each
+	// time it is redelivered.
+	Attempt int
+
+	state *ackState[T]
+	acks  chan<- *ackState[T]
+	done  <-chan struct{}
+}
+
+// ackState is what the deliveries of one result share.
+type ackState[T any] struct {
+	res      Optional[T]
+	attempts int
+	acked    atomic.Bool // Set by Ack, or when the result is dead-lettered
+	timer    *time.Timer // Only touched by the GoAcked goroutine
+}
+
+// Ack acknowledges the result, so it isn't delivered again. Acknowledging any
+// of a result's deliveries will do, and acknowledging it more than once, or
+// after it was dead-lettered, does nothing.
+func (d Delivery[T]) Ack() {
+	if d.state.acked.CompareAndSwap(false, true) {
+		select {
+		case d.acks <- d.state:
+		case <-d.done:
+		}
+	}
+}
+
+// GoAcked runs the pool and delivers its results for at-least-once
+// processing. A result that isn't acknowledged within timeout of being
+// delivered is delivered again, up to maxDeliveries times in all, after which
+// it is handed to deadLetter instead, if it isn't nil. The channel closes once
+// the pool is done and every result was either acknowledged or dead-lettered.
+func (g *Pool[T]) GoAcked(timeout time.Duration, maxDeliveries int, deadLetter func(Optional[T])) <-chan Delivery[T] {
+	in := g.Go()
+	out := make(chan Delivery[T])
+	acks := make(chan *ackState[T])
+	expired := make(chan *ackState[T])
+	done := make(chan struct{})
+	deliver := func(st *ackState[T]) Delivery[T] {
+		st.attempts++
+		return Delivery[T]{
+			Optional: st.res,
+			Attempt:  st.attempts,
+			state:    st,
+			acks:     acks,
+			done:     done,
+		}
+	}
+
+	go func() {
+		defer close(done)
+		defer close(out)
+		var queue []Delivery[T]
+		outstanding := 0
+		for in != nil || outstanding > 0 {
+			for len(queue) > 0 && queue[0].state.acked.Load() {
+				queue = queue[1:] // Acked while waiting to be redelivered
+			}
+			var send chan Delivery[T]
+			var next Delivery[T]
+			if len(queue) > 0 {
+				send, next = out, queue[0]
+			}
+			select {
+			case res, ok := <-in:
+				if !ok {
+					in = nil
+					continue
+				}
+				outstanding++
+				queue = append(queue, deliver(&ackState[T]{res: res}))
+			case send <- next:
+				queue = queue[1:]
+				st := next.state
+				st.timer = time.AfterFunc(timeout, func() {
+					select {
+					case expired <- st:
+					case <-done:
+					}
+				})
+			case st := <-acks:
+				outstanding--
+				st.timer.Stop()
+			case st := <-expired:
+				if st.acked.Load() {
+					continue // Its ack is on its way
+				}
+				if st.attempts < maxDeliveries {
+					queue = append(queue, deliver(st))
+					continue
+				}
+				if st.acked.CompareAndSwap(false, true) {
+					outstanding--
+					if deadLetter != nil {
+						deadLetter(st.res)
+					}
+				}
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,62 @@
+package gogo
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGoAcked(t *testing.T) {
+	pool := func(size int) *Pool[int] {
+		return NewPool(2, size, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		})
+	}
+
+	Convey("Given a result that isn't acknowledged, it should be delivered again", t, func() {
+		attempts := map[int][]int{}
+		for d := range pool(5).GoAcked(20*time.Millisecond, 3, nil) {
+			attempts[d.Result] = append(attempts[d.Result], d.Attempt)
+			if d.Result == 2 && d.Attempt == 1 {
+				continue // Drop it on the floor
+			}
+			d.Ack()
+		}
+		So(attempts, ShouldHaveLength, 5)
+		So(attempts[2], ShouldResemble, []int{1, 2})
+		So(attempts[3], ShouldResemble, []int{1})
+	})
+
+	Convey("Given a result that is never acknowledged, it should end up in the dead letter", t, func() {
+		var mu sync.Mutex
+		var dead []int
+		deliveries := 0
+		for d := range pool(3).GoAcked(10*time.Millisecond, 2, func(res Optional[int]) {
+			mu.Lock()
+			dead = append(dead, res.Result)
+			mu.Unlock()
+		}) {
+			deliveries++
+			if d.Result != 0 {
+				d.Ack()
+			}
+		}
+		So(deliveries, ShouldEqual, 4)
+		So(dead, ShouldResemble, []int{0})
+	})
+
+	Convey("Given acks after a result was dead-lettered, they should be ignored", t, func() {
+		var late []Delivery[int]
+		for d := range pool(2).GoAcked(5*time.Millisecond, 1, nil) {
+			late = append(late, d)
+		}
+		So(late, ShouldHaveLength, 2)
+		for _, d := range late {
+			d.Ack()
+		}
+	})
+}
@@ -0,0 +1,53 @@
+package gogo
+
+import (
+	"time"
+)
+
+// Batch runs the pool and hands its results to fn in batches of batchSize, as
+// they arrive. The last batch may be smaller. Batch returns once the feed is
+// closed and every batch has been handed over.
+func (g *Pool[T]) Batch(batchSize int, fn func([]Optional[T])) {
+	g.BatchTimed(batchSize, 0, fn)
+}
+
+// BatchTimed is like Batch, but a batch is also handed over once maxWait has
+// passed since its first result arrived, so a slow trickle of results is not
+// held back indefinitely. A maxWait of 0 disables the timer.
+func (g *Pool[T]) BatchTimed(batchSize int, maxWait time.Duration, fn func([]Optional[T])) {
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	feed := g.Go()
+	var batch []Optional[T]
+	var timer *time.Timer
+	var timeout <-chan time.Time
+	flush := func() {
+		if timer != nil {
+			timer.Stop()
+			timer, timeout = nil, nil
+		}
+		if len(batch) > 0 {
+			fn(batch)
+			batch = nil
+		}
+	}
+	for {
+		select {
+		case res, ok := <-feed:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, res)
+			if len(batch) >= batchSize {
+				flush()
+			} else if len(batch) == 1 && maxWait > 0 {
+				timer = time.NewTimer(maxWait)
+				timeout = timer.C
+			}
+		case <-timeout:
+			flush()
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package gogo
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestBatch(t *testing.T) {
+	Convey("Given a pool of 10, Batch should hand over full batches and a final partial one", t, func() {
+		pool := NewPool(3, 10, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		})
+		var sizes []int
+		pool.Batch(4, func(batch []Optional[int]) {
+			sizes = append(sizes, len(batch))
+		})
+		So(sizes, ShouldResemble, []int{4, 4, 2})
+	})
+
+	Convey("Given a slow trickle of results, BatchTimed should flush partial batches after maxWait", t, func() {
+		pool := NewPool(1, 4, func(i int) func() (int, error) {
+			return func() (int, error) {
+				time.Sleep(60 * time.Millisecond)
+				return i, nil
+			}
+		})
+		var sizes []int
+		pool.BatchTimed(10, 20*time.Millisecond, func(batch []Optional[int]) {
+			sizes = append(sizes, len(batch))
+		})
+		So(sizes, ShouldResemble, []int{1, 1, 1, 1})
+	})
+
+	Convey("Given a fast pool, BatchTimed should still flush on size and never hand over an empty batch", t, func() {
+		pool := NewPool(2, 6, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		})
+		var sizes []int
+		pool.BatchTimed(3, time.Second, func(batch []Optional[int]) {
+			sizes = append(sizes, len(batch))
+		})
+		So(sizes, ShouldResemble, []int{3, 3})
+	})
+}
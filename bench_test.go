@@ -0,0 +1,90 @@
+package gogo
+
+import (
+	"testing"
+)
+
+func benchmarkPool(b *testing.B, concurrency int, size int) {
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		pool := NewPool(concurrency, size, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		})
+		for range pool.Go() {
+		}
+	}
+}
+
+func BenchmarkPoolTinyTasks(b *testing.B) {
+	benchmarkPool(b, 8, 10000)
+}
+
+func BenchmarkPoolTinyTasksHighConcurrency(b *testing.B) {
+	benchmarkPool(b, 256, 10000)
+}
+
+func squares(size int) *Pool[int] {
+	return NewPool(8, size, func(i int) func() (int, error) {
+		return func() (int, error) {
+			return i * i, nil
+		}
+	})
+}
+
+func BenchmarkCollectArray(b *testing.B) {
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		CollectArray(squares(10000))
+	}
+}
+
+func BenchmarkCollectAppend(b *testing.B) {
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		var results []Optional[int]
+		for res := range squares(10000).Go() {
+			results = append(results, res)
+		}
+	}
+}
+
+func BenchmarkPoolMillionTasks(b *testing.B) {
+	benchmarkPool(b, 8, 1000000)
+}
+
+type largeResult struct {
+	payload [10 << 10]byte
+}
+
+func largeResults(size int) *Pool[largeResult] {
+	return NewPool(8, size, func(i int) func() (largeResult, error) {
+		return func() (largeResult, error) {
+			var res largeResult
+			res.payload[0] = byte(i)
+			return res, nil
+		}
+	})
+}
+
+// The consumer keeps every result, as a consumer collecting results would.
+func BenchmarkPoolLargeResults(b *testing.B) {
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		var kept []Optional[largeResult]
+		for res := range largeResults(1000).Go() {
+			kept = append(kept, res)
+		}
+	}
+}
+
+func BenchmarkPoolLargeResultsPtr(b *testing.B) {
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		var kept []*Optional[largeResult]
+		for res := range largeResults(1000).GoPtr() {
+			kept = append(kept, res)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package gogo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTimeBudget(t *testing.T) {
+	Convey("Given fast tasks, WithTimeBudget should give later tasks a larger share", t, func() {
+		timeouts := make([]time.Duration, 4)
+		pool := NewPoolContext(context.Background(), 1, 4, func(i int) func(ctx context.Context) (int, error) {
+			return func(ctx context.Context) (int, error) {
+				deadline, _ := ctx.Deadline()
+				timeouts[i] = time.Until(deadline)
+				return i, nil
+			}
+		}).WithTimeBudget(400 * time.Millisecond)
+		pool.Wait()
+		So(timeouts[0], ShouldBeBetween, 80*time.Millisecond, 100*time.Millisecond)
+		So(timeouts[1], ShouldBeBetween, 110*time.Millisecond, 134*time.Millisecond)
+		So(timeouts[2], ShouldBeBetween, 180*time.Millisecond, 200*time.Millisecond)
+		So(timeouts[3], ShouldBeBetween, 380*time.Millisecond, 400*time.Millisecond)
+	})
+
+	Convey("Given a slow first task, WithTimeBudget should shrink the timeouts of later tasks", t, func() {
+		timeouts := make([]time.Duration, 3)
+		pool := NewPoolContext(context.Background(), 1, 3, func(i int) func(ctx context.Context) (int, error) {
+			return func(ctx context.Context) (int, error) {
+				deadline, _ := ctx.Deadline()
+				timeouts[i] = time.Until(deadline)
+				if i == 0 {
+					<-ctx.Done()
+					time.Sleep(100 * time.Millisecond)
+					return 0, ctx.Err()
+				}
+				return i, nil
+			}
+		}).WithTimeBudget(300 * time.Millisecond)
+		var errs []error
+		for res := range pool.Go() {
+			errs = append(errs, res.Error)
+		}
+		So(errs[0], ShouldEqual, context.DeadlineExceeded)
+		So(timeouts[0], ShouldBeBetween, 80*time.Millisecond, 100*time.Millisecond)
+		// 300ms - 200ms spent leaves 100ms for two tasks
+		So(timeouts[1], ShouldBeBetween, 30*time.Millisecond, 50*time.Millisecond)
+		So(timeouts[2], ShouldBeBetween, 80*time.Millisecond, 100*time.Millisecond)
+	})
+
+	Convey("Given a pool without a budget, tasks should have no deadline", t, func() {
+		pool := NewPoolContext(context.Background(), 2, 2, func(i int) func(ctx context.Context) (bool, error) {
+			return func(ctx context.Context) (bool, error) {
+				_, ok := ctx.Deadline()
+				return ok, nil
+			}
+		})
+		for res := range pool.Go() {
+			So(res.Result, ShouldBeFalse)
+		}
+	})
+}
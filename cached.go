@@ -0,0 +1,68 @@
+package gogo
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CachedValue is a value kept fresh in the background by Cached.
+type CachedValue[T any] struct {
+	mu      sync.Mutex
+	value   T
+	fetched time.Time
+	err     error
+	ready   chan struct{} // Closed once the first fetch is done
+}
+
+// Cached runs fn straight away and then again every refresh, until ctx is
+// done, keeping the latest good value. A failed refresh keeps the previous
+// value, which then just gets older. A refresh of zero or less only fetches
+// the value once.
+func Cached[T any](ctx context.Context, refresh time.Duration, fn func(ctx context.Context) (T, error)) *CachedValue[T] {
+	c := &CachedValue[T]{
+		ready: make(chan struct{}),
+	}
+	go func() {
+		c.fetch(ctx, fn)
+		close(c.ready)
+		if refresh <= 0 {
+			return
+		}
+		ticker := time.NewTicker(refresh)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				c.fetch(ctx, fn)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return c
+}
+
+func (c *CachedValue[T]) fetch(ctx context.Context, fn func(ctx context.Context) (T, error)) {
+	value, err := fn(ctx)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err != nil {
+		c.err = err
+		return
+	}
+	c.value, c.fetched, c.err = value, time.Now(), nil
+}
+
+// Get returns the latest good value and how long ago it was fetched. It waits
+// for the first fetch, and only fails if no fetch has succeeded yet, with the
+// error of the latest one.
+func (c *CachedValue[T]) Get() (value T, age time.Duration, err error) {
+	<-c.ready
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.fetched.IsZero() {
+		return value, 0, c.err
+	}
+	return c.value, time.Since(c.fetched), nil
+}
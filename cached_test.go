@@ -0,0 +1,78 @@
+package gogo
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCached(t *testing.T) {
+	Convey("Given a cached value, Get should age between refreshes and pick up new values", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var fetches int32
+		cache := Cached(ctx, 50*time.Millisecond, func(ctx context.Context) (int32, error) {
+			return atomic.AddInt32(&fetches, 1), nil
+		})
+		value, age, err := cache.Get()
+		So(err, ShouldBeNil)
+		So(value, ShouldEqual, 1)
+
+		time.Sleep(20 * time.Millisecond)
+		value, older, err := cache.Get()
+		So(err, ShouldBeNil)
+		So(value, ShouldEqual, 1)
+		So(older, ShouldBeGreaterThan, age)
+
+		time.Sleep(60 * time.Millisecond)
+		value, _, _ = cache.Get()
+		So(value, ShouldBeGreaterThan, 1)
+	})
+
+	Convey("Given refreshes that fail, Get should serve the stale value", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var fetches int32
+		cache := Cached(ctx, 10*time.Millisecond, func(ctx context.Context) (string, error) {
+			if atomic.AddInt32(&fetches, 1) > 1 {
+				return "", errors.New("upstream down")
+			}
+			return "good", nil
+		})
+		cache.Get() // Wait for the first fetch
+		time.Sleep(50 * time.Millisecond)
+		value, age, err := cache.Get()
+		So(err, ShouldBeNil)
+		So(value, ShouldEqual, "good")
+		So(age, ShouldBeGreaterThanOrEqualTo, 50*time.Millisecond)
+		So(atomic.LoadInt32(&fetches), ShouldBeGreaterThan, 2)
+	})
+
+	Convey("Given a first fetch that fails, Get should return its error", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		errDown := errors.New("upstream down")
+		_, _, err := Cached(ctx, time.Second, func(ctx context.Context) (int, error) {
+			return 0, errDown
+		}).Get()
+		So(err, ShouldEqual, errDown)
+	})
+
+	Convey("Given a refresh of zero, the value should be fetched once and kept", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var fetches atomic.Int32
+		cached := Cached(ctx, 0, func(ctx context.Context) (int32, error) {
+			return fetches.Add(1), nil
+		})
+		value, _, err := cached.Get()
+		So(err, ShouldBeNil)
+		So(value, ShouldEqual, 1)
+		time.Sleep(20 * time.Millisecond)
+		So(fetches.Load(), ShouldEqual, 1)
+	})
+}
@@ -0,0 +1,121 @@
+package gogo
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrPoolCancelled is the cause a pool's context is cancelled with by
+	// Cancel.
+	ErrPoolCancelled = errors.New("gogo: pool cancelled")
+	// ErrMaxErrorsReached is the cause a pool's context is cancelled with by
+	// WithMaxErrors.
+	ErrMaxErrorsReached = errors.New("gogo: pool reached its error limit")
+	// ErrChainPanicked is the cause the pools of a pipeline are cancelled with
+	// when one of its Chain stages panics.
+	ErrChainPanicked = errors.New("gogo: chain stage panicked")
+)
+
+// CancelReason says why a pool was cancelled.
+type CancelReason int
+
+const (
+	// CancelNone means the pool hasn't been cancelled.
+	CancelNone CancelReason = iota
+	// CancelUser is a call to Cancel, including the ones made by Iterate and
+	// ForEachCtx when they stop early.
+	CancelUser
+	// CancelTimeout is the deadline set with WithTimeout passing.
+	CancelTimeout
+	// CancelParent is the context the pool was created with, or the one a
+	// chained pool was given by Chain, being done.
+	CancelParent
+	// CancelMaxErrors is the limit set with WithMaxErrors being reached.
+	CancelMaxErrors
+	// CancelPanic is a Chain stage panicking, which cancels the stage's pool
+	// and the pool it takes its input from.
+	CancelPanic
+)
+
+func (r CancelReason) String() string {
+	switch r {
+	case CancelNone:
+		return "none"
+	case CancelUser:
+		return "user cancel"
+	case CancelTimeout:
+		return "timeout"
+	case CancelParent:
+		return "parent cancel"
+	case CancelMaxErrors:
+		return "max errors"
+	case CancelPanic:
+		return "chain panic"
+	}
+	return fmt.Sprintf("CancelReason(%d)", int(r))
+}
+
+// cause is what the pool's context is cancelled with when it is cancelled for
+// reason r.
+func (r CancelReason) cause() error {
+	switch r {
+	case CancelMaxErrors:
+		return ErrMaxErrorsReached
+	case CancelPanic:
+		return ErrChainPanicked
+	}
+	return ErrPoolCancelled
+}
+
+// CancelError is what a task's error is wrapped in when the task fails with
+// context.Canceled or context.DeadlineExceeded after its pool was cancelled.
+// errors.Is still matches the original error.
+type CancelError struct {
+	Reason CancelReason
+	Cause  error // context.Cause of the pool's context
+	Err    error
+}
+
+func (e *CancelError) Error() string {
+	return fmt.Sprintf("gogo: task cancelled (%s): %v", e.Reason, e.Err)
+}
+
+func (e *CancelError) Unwrap() error {
+	return e.Err
+}
+
+// CancelCause returns the cause the pool's context was cancelled with, if err
+// is a task's error wrapped in a CancelError, and nil otherwise. It tells a
+// Cancel (ErrPoolCancelled) from a timeout (wrapping
+// context.DeadlineExceeded, or the cause given to WithTimeoutCause) or a
+// cancelled parent context (whatever its cause is).
+func CancelCause(err error) error {
+	var cancelErr *CancelError
+	if errors.As(err, &cancelErr) {
+		return cancelErr.Cause
+	}
+	return nil
+}
+
+// CancelReason returns why the pool was cancelled, or CancelNone if it
+// wasn't. Whichever cancellation came first wins.
+func (g *Pool[T]) CancelReason() CancelReason {
+	if reason := CancelReason(g.cancelReason.Load()); reason != CancelNone {
+		return reason
+	}
+	switch {
+	case g.ctx.Err() == nil:
+		return CancelNone
+	case g.parent.Err() != nil:
+		return CancelParent
+	}
+	return CancelTimeout
+}
+
+// Cancelled reports whether the pool was cancelled, by any of the reasons
+// CancelReason tells apart. A pool that finished without being cancelled ran
+// every task; one that was cancelled may have stopped short, see Completed.
+func (g *Pool[T]) Cancelled() bool {
+	return g.CancelReason() != CancelNone
+}
@@ -0,0 +1,209 @@
+package gogo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCancelReason(t *testing.T) {
+	// blocking returns a pool whose tasks wait for their context to be done.
+	blocking := func(ctx context.Context, size int) *Pool[int] {
+		return NewPoolContext(ctx, size, size, func(i int) func(ctx context.Context) (int, error) {
+			return func(ctx context.Context) (int, error) {
+				<-ctx.Done()
+				return 0, ctx.Err()
+			}
+		})
+	}
+	reasons := func(pool *Pool[int]) []CancelReason {
+		var reasons []CancelReason
+		for res := range pool.Go() {
+			var cancelErr *CancelError
+			if errors.As(res.Error, &cancelErr) {
+				reasons = append(reasons, cancelErr.Reason)
+			}
+		}
+		return reasons
+	}
+
+	Convey("Given a pool that finishes on its own, there should be no cancel reason", t, func() {
+		pool := NewPool(2, 4, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		})
+		pool.Wait()
+		So(pool.CancelReason(), ShouldEqual, CancelNone)
+	})
+
+	Convey("Given a call to Cancel, the reason should be CancelUser", t, func() {
+		pool := blocking(context.Background(), 2)
+		time.AfterFunc(20*time.Millisecond, pool.Cancel)
+		So(reasons(pool), ShouldResemble, []CancelReason{CancelUser, CancelUser})
+		So(pool.CancelReason(), ShouldEqual, CancelUser)
+	})
+
+	Convey("Given a pool timeout, the reason should be CancelTimeout", t, func() {
+		pool := blocking(context.Background(), 1).WithTimeout(20 * time.Millisecond)
+		res := <-pool.Go()
+		So(res.Error, ShouldWrap, context.DeadlineExceeded)
+		So(res.Error.(*CancelError).Reason, ShouldEqual, CancelTimeout)
+	})
+
+	Convey("Given a parent context that is cancelled, the reason should be CancelParent", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		pool := blocking(ctx, 2)
+		time.AfterFunc(20*time.Millisecond, cancel)
+		So(reasons(pool), ShouldResemble, []CancelReason{CancelParent, CancelParent})
+	})
+
+	Convey("Given a parent deadline, the reason should still be CancelParent", t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		pool := blocking(ctx, 1).WithTimeout(time.Hour)
+		So(reasons(pool), ShouldResemble, []CancelReason{CancelParent})
+	})
+
+	Convey("Given WithMaxErrors, the reason should be CancelMaxErrors", t, func() {
+		errBoom := errors.New("boom")
+		pool := NewPoolContext(context.Background(), 3, 3, func(i int) func(ctx context.Context) (int, error) {
+			return func(ctx context.Context) (int, error) {
+				if i == 0 {
+					time.Sleep(20 * time.Millisecond) // Let the others start
+					return 0, errBoom
+				}
+				<-ctx.Done()
+				return 0, ctx.Err()
+			}
+		}).WithMaxErrors(1)
+		So(reasons(pool), ShouldResemble, []CancelReason{CancelMaxErrors, CancelMaxErrors})
+		So(pool.CancelReason(), ShouldEqual, CancelMaxErrors)
+	})
+
+	Convey("Given the context given to Chain being cancelled, the chained pool's reason should be CancelParent", t, func() {
+		ctx, cancel := context.WithCancelCause(context.Background())
+		errShutdown := errors.New("shutting down")
+		source := NewPool(2, 2, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		})
+		chained := Chain(ctx, source, 2, func(ctx context.Context, value int) (int, error) {
+			<-ctx.Done()
+			return 0, ctx.Err()
+		})
+		time.AfterFunc(20*time.Millisecond, func() { cancel(errShutdown) })
+		So(reasons(chained), ShouldResemble, []CancelReason{CancelParent, CancelParent})
+		So(chained.CancelReason(), ShouldEqual, CancelParent)
+		So(context.Cause(chained.ctx), ShouldEqual, errShutdown)
+	})
+
+	Convey("Given a Chain stage that panics, both pools' reason should be CancelPanic", t, func() {
+		source := NewPool(1, 2, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		})
+		chained := Chain(context.Background(), source, 1, func(ctx context.Context, value int) (int, error) {
+			panic("bad record")
+		})
+		chained.Wait()
+		So(source.CancelReason(), ShouldEqual, CancelPanic)
+		So(chained.CancelReason(), ShouldEqual, CancelPanic)
+		So(context.Cause(chained.ctx), ShouldEqual, ErrChainPanicked)
+		So(CancelPanic.String(), ShouldEqual, "chain panic")
+	})
+
+	Convey("Given a cancel after a timeout, the first reason should stick", t, func() {
+		pool := blocking(context.Background(), 1).WithTimeout(10 * time.Millisecond)
+		pool.Wait()
+		pool.Cancel()
+		So(pool.CancelReason(), ShouldEqual, CancelTimeout)
+	})
+
+	Convey("Given an error that isn't the context's, it should not be wrapped", t, func() {
+		errBoom := errors.New("boom")
+		var pool *Pool[int]
+		pool = NewPoolContext(context.Background(), 1, 1, func(i int) func(ctx context.Context) (int, error) {
+			return func(ctx context.Context) (int, error) {
+				pool.Cancel()
+				return 0, errBoom
+			}
+		})
+		res := <-pool.Go()
+		So(res.Error, ShouldEqual, errBoom)
+		So(CancelMaxErrors.String(), ShouldEqual, "max errors")
+	})
+
+	Convey("Given a cancelled pool, tasks and consumers should see why through the cause", t, func() {
+		causes := func(pool *Pool[int]) []error {
+			var causes []error
+			for res := range pool.Go() {
+				causes = append(causes, CancelCause(res.Error))
+			}
+			return causes
+		}
+
+		pool := NewPoolContext(context.Background(), 1, 1, func(i int) func(ctx context.Context) (int, error) {
+			return func(ctx context.Context) (int, error) {
+				<-ctx.Done()
+				if context.Cause(ctx) != ErrPoolCancelled {
+					return 0, errors.New("task saw the wrong cause")
+				}
+				return 0, ctx.Err()
+			}
+		})
+		time.AfterFunc(10*time.Millisecond, pool.Cancel)
+		So(causes(pool), ShouldResemble, []error{ErrPoolCancelled})
+
+		pool = blocking(context.Background(), 1).WithTimeout(10 * time.Millisecond)
+		cause := causes(pool)[0]
+		So(cause, ShouldWrap, context.DeadlineExceeded)
+		So(cause.Error(), ShouldEqual, "gogo: pool timed out after 10ms: context deadline exceeded")
+
+		errSlow := errors.New("too slow")
+		pool = blocking(context.Background(), 1).WithTimeoutCause(10*time.Millisecond, errSlow)
+		So(causes(pool), ShouldResemble, []error{errSlow})
+
+		pool = NewPoolContext(context.Background(), 2, 2, func(i int) func(ctx context.Context) (int, error) {
+			return func(ctx context.Context) (int, error) {
+				if i == 0 {
+					time.Sleep(10 * time.Millisecond)
+					return 0, errors.New("boom")
+				}
+				<-ctx.Done()
+				return 0, ctx.Err()
+			}
+		}).WithMaxErrors(1)
+		So(causes(pool), ShouldContain, ErrMaxErrorsReached)
+
+		So(CancelCause(errors.New("not cancelled")), ShouldBeNil)
+	})
+
+	Convey("Given a pool cut short, Cancelled and Completed should say it is partial", t, func() {
+		pool := NewPool(1, 10, func(i int) func() (int, error) {
+			return func() (int, error) {
+				time.Sleep(10 * time.Millisecond)
+				return i, nil
+			}
+		})
+		So(pool.Cancelled(), ShouldBeFalse)
+		time.AfterFunc(25*time.Millisecond, pool.Cancel)
+		pool.Wait()
+		So(pool.Cancelled(), ShouldBeTrue)
+		So(pool.Completed(), ShouldBeBetweenOrEqual, 1, 5)
+
+		full := NewPool(2, 10, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		})
+		full.Wait()
+		So(full.Cancelled(), ShouldBeFalse)
+		So(full.Completed(), ShouldEqual, 10)
+	})
+}
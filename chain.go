@@ -0,0 +1,133 @@
+package gogo
+
+import (
+	"context"
+	"sync"
+)
+
+// Chain pipes the results of pool into a new pool that runs fn on each of them
+// with the given concurrency. Errors from pool are forwarded to the new pool's
+// feed without calling fn. If pool's feed closes early, for instance because
+// it was cancelled, the new pool simply ends up with fewer results.
+//
+// The new pool's context is derived from pool's, so values and deadlines set
+// on pool with WithValue or WithTimeout reach the chained tasks too; configure
+// pool before chaining it. The new pool is also cancelled when ctx is done
+// before it finishes, unless ctx is nil. Use ChainIn to run it under a
+// context of your own instead.
+//
+// pool is not started until the new pool is, so a chain that is built but
+// never run does no work.
+//
+// If fn panics, both pool and the new pool are cancelled, so the whole pipeline
+// winds down, and the task fails with a *PanicError like any other pool task
+// that panics. Further chained stages forward it like any other error.
+func Chain[T any, U any](ctx context.Context, pool *Pool[T], concurrency int, fn func(ctx context.Context, value T) (U, error)) *Pool[U] {
+	return ChainN(ctx, pool, concurrency, pool.size, fn)
+}
+
+// ChainN is like Chain, but the new pool has size tasks instead of one for
+// each task of pool. Each task takes one result from pool, so a size below
+// pool's only takes its first size results and leaves the rest on its feed,
+// while the extra tasks of a larger size deliver nothing. The size matters
+// when pool delivers fewer results than it has tasks, for instance because it
+// has a Filter: it sets the new pool's feed buffer and the total its progress
+// and stats count towards.
+func ChainN[T any, U any](ctx context.Context, pool *Pool[T], concurrency int, size int, fn func(ctx context.Context, value T) (U, error)) *Pool[U] {
+	chained := chain(pool.ctx, pool, concurrency, size, fn)
+	switch {
+	case ctx == nil:
+	case ctx.Err() != nil:
+		// Before it can start, not just soon after
+		chained.cancelWithCause(CancelParent, context.Cause(ctx))
+	default:
+		// Don't keep the pool around for as long as ctx lives
+		stop := context.AfterFunc(ctx, func() {
+			chained.cancelWithCause(CancelParent, context.Cause(ctx))
+		})
+		chained.onClose = append(chained.onClose, func() { stop() })
+	}
+	return chained
+}
+
+// ChainIn is like Chain, but the new pool's context is derived from ctx alone,
+// ignoring pool's. A nil ctx falls back to pool's.
+func ChainIn[T any, U any](ctx context.Context, pool *Pool[T], concurrency int, fn func(ctx context.Context, value T) (U, error)) *Pool[U] {
+	if ctx == nil {
+		ctx = pool.ctx
+	}
+	return chain(ctx, pool, concurrency, pool.size, fn)
+}
+
+func chain[T any, U any](ctx context.Context, pool *Pool[T], concurrency int, size int, fn func(ctx context.Context, value T) (U, error)) *Pool[U] {
+	feed := sync.OnceValue(pool.Go)
+	var chained *Pool[U]
+	chained = NewPoolContext(ctx, concurrency, size, func(i int) func(ctx context.Context) (U, error) {
+		return func(ctx context.Context) (res U, err error) {
+			in, ok := <-feed()
+			for ok && in.isKeepAlive() {
+				in, ok = <-feed()
+			}
+			if !ok {
+				return res, errSkip
+			}
+			if in.Error != nil {
+				return res, in.Error
+			}
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					// The chained pool first, as its context derives from pool's
+					chained.cancelWith(CancelPanic)
+					pool.cancelWith(CancelPanic)
+					panic(recovered) // For the pool to handle as usual
+				}
+			}()
+			return fn(ctx, in.Result)
+		}
+	})
+	return chained
+}
+
+// ChainWith runs fn on results from both feed and extra, with the given
+// concurrency, and returns a channel of the outputs. The two inputs are merged
+// fairly, so a busy feed does not starve extra; this makes it possible to
+// reinject retried items or other out-of-band work into a pipeline. Errors
+// from either input are forwarded without calling fn. The returned channel
+// closes once both inputs are closed and drained, or once ctx is done.
+func ChainWith[T any, U any](ctx context.Context, feed <-chan Optional[T], extra <-chan Optional[T], concurrency int, fn func(ctx context.Context, value T) (U, error)) <-chan Optional[U] {
+	merged := make(chan Optional[T])
+	go func() {
+		defer close(merged)
+		for feed != nil || extra != nil {
+			var res Optional[T]
+			var ok bool
+			select {
+			case res, ok = <-feed:
+				if !ok {
+					feed = nil
+					continue
+				}
+			case res, ok = <-extra:
+				if !ok {
+					extra = nil
+					continue
+				}
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case merged <- res:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return MapChan(ctx, concurrency, merged, func(ctx context.Context, res Optional[T]) (U, error) {
+		if res.Error != nil {
+			var zero U
+			return zero, res.Error
+		}
+		return fn(ctx, res.Result)
+	})
+}
@@ -0,0 +1,323 @@
+package gogo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestChain(t *testing.T) {
+	Convey("Given a chained pool, every source result should be transformed", t, func() {
+		source := NewPool(2, 5, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		})
+		chained := Chain(context.Background(), source, 3, func(ctx context.Context, value int) (string, error) {
+			return strconv.Itoa(value * 2), nil
+		})
+		var results []string
+		for res := range chained.Go() {
+			So(res.Error, ShouldBeNil)
+			results = append(results, res.Result)
+		}
+		So(results, ShouldHaveLength, 5)
+		So(results, ShouldContain, "8")
+	})
+
+	Convey("Given a failing source task, Chain should forward its error", t, func() {
+		source := NewPool(2, 3, func(i int) func() (int, error) {
+			return func() (int, error) {
+				if i == 1 {
+					return 0, errors.New("source failed")
+				}
+				return i, nil
+			}
+		})
+		calls := make(chan int, 3)
+		chained := Chain(context.Background(), source, 1, func(ctx context.Context, value int) (int, error) {
+			calls <- value
+			return value, nil
+		})
+		var errs []error
+		for res := range chained.Go() {
+			if res.Error != nil {
+				errs = append(errs, res.Error)
+			}
+		}
+		So(errs, ShouldHaveLength, 1)
+		So(errs[0].Error(), ShouldEqual, "source failed")
+		So(calls, ShouldHaveLength, 2)
+	})
+
+	Convey("Given a source that is cancelled early, the chained pool should still finish", t, func() {
+		source := NewPool(1, 10, func(i int) func() (int, error) {
+			return func() (int, error) {
+				time.Sleep(20 * time.Millisecond)
+				return i, nil
+			}
+		})
+		chained := Chain(context.Background(), source, 4, func(ctx context.Context, value int) (int, error) {
+			return value, nil
+		})
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			source.Cancel()
+		}()
+		done := make(chan int)
+		go func() {
+			count := 0
+			for range chained.Go() {
+				count++
+			}
+			done <- count
+		}()
+		select {
+		case count := <-done:
+			So(count, ShouldBeLessThan, 10)
+		case <-time.After(2 * time.Second):
+			So("chained pool hung", ShouldBeEmpty)
+		}
+	})
+
+	Convey("Given a chain that is never run, the source pool should not start", t, func() {
+		var ran int32
+		source := NewPool(2, 5, func(i int) func() (int, error) {
+			return func() (int, error) {
+				atomic.AddInt32(&ran, 1)
+				return i, nil
+			}
+		})
+		chained := Chain(context.Background(), source, 2, func(ctx context.Context, value int) (int, error) {
+			return value, nil
+		})
+		time.Sleep(50 * time.Millisecond)
+		So(source.started.Load(), ShouldBeFalse)
+		So(atomic.LoadInt32(&ran), ShouldEqual, 0)
+
+		count := 0
+		for range chained.Go() {
+			count++
+		}
+		So(source.started.Load(), ShouldBeTrue)
+		So(count, ShouldEqual, 5)
+	})
+
+	Convey("Given a middle stage that panics, the whole pipeline should stop with the panic error", t, func() {
+		var launched int32
+		source := NewPool(2, 100, func(i int) func() (int, error) {
+			return func() (int, error) {
+				atomic.AddInt32(&launched, 1)
+				time.Sleep(time.Millisecond)
+				return i, nil
+			}
+		})
+		middle := Chain(context.Background(), source, 2, func(ctx context.Context, value int) (int, error) {
+			if value == 5 {
+				panic("bad record")
+			}
+			return value * 2, nil
+		})
+		final := Chain(context.Background(), middle, 2, func(ctx context.Context, value int) (string, error) {
+			return strconv.Itoa(value), nil
+		})
+		var panicErr *PanicError
+		for res := range final.Go() {
+			if res.Error != nil {
+				So(errors.As(res.Error, &panicErr), ShouldBeTrue)
+			}
+		}
+		So(panicErr, ShouldNotBeNil)
+		So(panicErr.Value, ShouldEqual, "bad record")
+		So(panicErr.Error(), ShouldEqual, fmt.Sprintf("gogo: task %d panicked: bad record", panicErr.Index))
+		So(source.ctx.Err(), ShouldNotBeNil)
+		So(middle.ctx.Err(), ShouldNotBeNil)
+		So(atomic.LoadInt32(&launched), ShouldBeLessThan, 100)
+	})
+
+	Convey("Given a context that is already cancelled, Chain should close without touching its source", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		source := NewPool(2, 10, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		})
+		var called int32
+		chained := Chain(ctx, source, 4, func(ctx context.Context, value int) (int, error) {
+			atomic.AddInt32(&called, 1)
+			return value, nil
+		})
+		count := 0
+		for range chained.Go() {
+			count++
+		}
+		So(count, ShouldEqual, 0)
+		So(atomic.LoadInt32(&called), ShouldEqual, 0)
+		So(source.started.Load(), ShouldBeFalse)
+	})
+
+	Convey("Given a chained pool that has finished, cancelling ctx afterwards should not touch it", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		source := NewPool(2, 4, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		})
+		chained := Chain(ctx, source, 2, func(ctx context.Context, value int) (int, error) {
+			return value, nil
+		})
+		chained.Wait()
+		cancel()
+		time.Sleep(10 * time.Millisecond) // AfterFunc would run on its own goroutine
+		So(chained.CancelReason(), ShouldEqual, CancelNone)
+	})
+
+	Convey("Given a filtered source, ChainN should size the chained pool to match", t, func() {
+		source := NewPool(3, 20, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		}).Filter(func(v int) bool {
+			return v%4 == 0
+		})
+		var progress []int
+		chained := ChainN(context.Background(), source, 2, 5, func(ctx context.Context, v int) (int, error) {
+			return v * 10, nil
+		}).OnProgress(func(completed, total int) {
+			progress = append(progress, total)
+		})
+		sum := 0
+		for res := range chained.Go() {
+			sum += res.Result
+		}
+		So(sum, ShouldEqual, (0+4+8+12+16)*10)
+		So(chained.Stats().Completed, ShouldEqual, 5)
+		So(progress, ShouldHaveLength, 5)
+		So(progress[0], ShouldEqual, 5)
+	})
+
+	Convey("Given a size below the source's, ChainN should only take that many results", t, func() {
+		source := NewPool(1, 10, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		})
+		chained := ChainN(context.Background(), source, 1, 3, func(ctx context.Context, v int) (int, error) {
+			return v, nil
+		})
+		var got []int
+		for res := range chained.Go() {
+			got = append(got, res.Result)
+		}
+		So(got, ShouldResemble, []int{0, 1, 2})
+	})
+
+	Convey("Given values set on the source pool, the chained tasks should see them", t, func() {
+		type key string
+		source := NewPool(2, 5, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		}).WithValue(key("reqID"), "abc")
+		chained := Chain(context.Background(), source, 2, func(ctx context.Context, value int) (string, error) {
+			reqID, _ := ctx.Value(key("reqID")).(string)
+			return reqID, nil
+		})
+		for res := range chained.Go() {
+			So(res.Error, ShouldBeNil)
+			So(res.Result, ShouldEqual, "abc")
+		}
+
+		Convey("Unless ChainIn runs the chained pool under its own context", func() {
+			source := NewPool(2, 5, func(i int) func() (int, error) {
+				return func() (int, error) {
+					return i, nil
+				}
+			}).WithValue(key("reqID"), "abc")
+			ctx := context.WithValue(context.Background(), key("reqID"), "override")
+			chained := ChainIn(ctx, source, 2, func(ctx context.Context, value int) (string, error) {
+				reqID, _ := ctx.Value(key("reqID")).(string)
+				return reqID, nil
+			})
+			for res := range chained.Go() {
+				So(res.Result, ShouldEqual, "override")
+			}
+		})
+	})
+
+	Convey("Given a chain whose context is cancelled while it runs, the chained pool should stop", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		source := NewPool(1, 100, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		})
+		chained := Chain(ctx, source, 1, func(ctx context.Context, value int) (int, error) {
+			if value == 2 {
+				cancel()
+				<-ctx.Done()
+			}
+			return value, ctx.Err()
+		})
+		count := 0
+		for range chained.Go() {
+			count++
+		}
+		So(count, ShouldBeLessThan, 100)
+	})
+}
+
+func TestChainWith(t *testing.T) {
+	Convey("Given a pool feed and an extra channel, ChainWith should process items from both", t, func() {
+		source := NewPool(2, 5, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		})
+		extra := make(chan Optional[int])
+		go func() {
+			for i := 100; i < 103; i++ {
+				extra <- Optional[int]{Result: i}
+			}
+			extra <- Optional[int]{Error: errors.New("injected failure")}
+			close(extra)
+		}()
+		out := ChainWith(context.Background(), source.Go(), extra, 3, func(ctx context.Context, value int) (int, error) {
+			return value + 1, nil
+		})
+		sum := 0
+		var errs []error
+		for res := range out {
+			if res.Error != nil {
+				errs = append(errs, res.Error)
+				continue
+			}
+			sum += res.Result
+		}
+		// (0+1 .. 4+1) + (100+1 .. 102+1)
+		So(sum, ShouldEqual, 15+306)
+		So(errs, ShouldHaveLength, 1)
+	})
+
+	Convey("Given a cancelled context, ChainWith should close its output", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		feed := make(chan Optional[int])
+		out := ChainWith(ctx, feed, nil, 2, func(ctx context.Context, value int) (int, error) {
+			return value, nil
+		})
+		cancel()
+		select {
+		case _, ok := <-out:
+			So(ok, ShouldBeFalse)
+		case <-time.After(time.Second):
+			So("output never closed", ShouldBeEmpty)
+		}
+	})
+}
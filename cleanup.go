@@ -0,0 +1,71 @@
+package gogo
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+)
+
+// ErrClaimed is returned by Use when the resource was already claimed by an
+// earlier Use.
+var ErrClaimed = errors.New("gogo: resource already claimed")
+
+// GoWithCleanup is like GoContext for functions that return a resource, like
+// a file or a response body. If ctx is done before the resource is claimed
+// with Use, it is closed, so a Proc that is abandoned on cancellation doesn't
+// leak it. If ctx is already done when fn returns, the Proc fails with ctx's
+// error instead of handing out a resource that is being closed.
+//
+// Result still returns the resource, but doesn't claim it.
+func GoWithCleanup[T io.Closer](ctx context.Context, fn func(ctx context.Context) (T, error)) *Proc[T] {
+	var stop func() bool
+	var claimed atomic.Bool
+	proc := &Proc[T]{
+		ctx: ctx,
+		claim: func() error {
+			if !claimed.CompareAndSwap(false, true) {
+				return ErrClaimed
+			}
+			if !stop() {
+				return ctx.Err() // Closed already
+			}
+			return nil
+		},
+	}
+	proc.fn = func(ctx context.Context) (T, error) {
+		res, err := fn(ctx)
+		if err != nil {
+			return res, err
+		}
+		stop = context.AfterFunc(ctx, func() {
+			res.Close()
+		})
+		if ctx.Err() != nil {
+			var zero T
+			return zero, ctx.Err()
+		}
+		return res, nil
+	}
+	go proc.Go()
+	return proc
+}
+
+// Use waits for p's resource, claims it so GoWithCleanup won't close it,
+// hands it to f and closes it once f returns. If the resource was already
+// closed because p's context was done, f isn't called and Use returns the
+// context's error; if an earlier Use claimed it, ErrClaimed. For Procs not
+// from GoWithCleanup, Use simply closes the result after f.
+func Use[T io.Closer](p *Proc[T], f func(T) error) error {
+	res, err := p.Result()
+	if err != nil {
+		return err
+	}
+	if p.claim != nil {
+		if err := p.claim(); err != nil {
+			return err
+		}
+	}
+	defer res.Close()
+	return f(res)
+}
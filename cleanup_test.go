@@ -0,0 +1,85 @@
+package gogo
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type testCloser struct {
+	closed atomic.Int32
+}
+
+func (c *testCloser) Close() error {
+	c.closed.Add(1)
+	return nil
+}
+
+func TestGoWithCleanup(t *testing.T) {
+	Convey("Given a Proc that is abandoned, its resource should be closed once the context is done", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		closer := &testCloser{}
+		proc := GoWithCleanup(ctx, func(ctx context.Context) (*testCloser, error) {
+			return closer, nil
+		})
+		proc.Wait()
+		So(closer.closed.Load(), ShouldEqual, 0)
+		cancel()
+		time.Sleep(10 * time.Millisecond)
+		So(closer.closed.Load(), ShouldEqual, 1)
+
+		Convey("And Use should report the context's error without calling f", func() {
+			called := false
+			err := Use(proc, func(*testCloser) error {
+				called = true
+				return nil
+			})
+			So(err, ShouldEqual, context.Canceled)
+			So(called, ShouldBeFalse)
+			So(closer.closed.Load(), ShouldEqual, 1)
+		})
+	})
+
+	Convey("Given a resource claimed with Use, it should be closed exactly once, after f", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		closer := &testCloser{}
+		proc := GoWithCleanup(ctx, func(ctx context.Context) (*testCloser, error) {
+			return closer, nil
+		})
+		err := Use(proc, func(c *testCloser) error {
+			So(c.closed.Load(), ShouldEqual, 0)
+			cancel() // Doesn't close a claimed resource
+			time.Sleep(10 * time.Millisecond)
+			So(c.closed.Load(), ShouldEqual, 0)
+			return nil
+		})
+		So(err, ShouldBeNil)
+		So(closer.closed.Load(), ShouldEqual, 1)
+		So(Use(proc, func(*testCloser) error { return nil }), ShouldEqual, ErrClaimed)
+	})
+
+	Convey("Given a function that returns after its context is done, the Proc should fail and close the resource", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		closer := &testCloser{}
+		proc := GoWithCleanup(ctx, func(ctx context.Context) (*testCloser, error) {
+			cancel()
+			return closer, nil
+		})
+		_, err := proc.Result()
+		So(err, ShouldEqual, context.Canceled)
+		time.Sleep(10 * time.Millisecond)
+		So(closer.closed.Load(), ShouldEqual, 1)
+	})
+
+	Convey("Given a function that fails, Use should return its error", t, func() {
+		errOpen := errors.New("open failed")
+		proc := GoWithCleanup(context.Background(), func(ctx context.Context) (*testCloser, error) {
+			return nil, errOpen
+		})
+		So(Use(proc, func(*testCloser) error { return nil }), ShouldEqual, errOpen)
+	})
+}
@@ -0,0 +1,110 @@
+package gogo
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+)
+
+// GroupBy runs pool to completion and buckets its successful results by the
+// key keyFn returns for them. Failed results are left out; create the pool
+// with NewErrorPool to read them back with Errors.
+func GroupBy[T any, K comparable](pool *Pool[T], keyFn func(T) K) map[K][]T {
+	groups := make(map[K][]T)
+	for res := range pool.Go() {
+		if res.Error != nil || res.isKeepAlive() {
+			continue
+		}
+		key := keyFn(res.Result)
+		groups[key] = append(groups[key], res.Result)
+	}
+	return groups
+}
+
+// CollectArray runs pool to completion and returns its results laid out by
+// task index, so results[i] is the outcome of task i. The slice is allocated
+// once at the pool's size. Tasks that never ran, because the pool was
+// cancelled, are left as the zero Optional. The error joins every failure.
+func CollectArray[T any](pool *Pool[T]) ([]Optional[T], error) {
+	results := make([]Optional[T], pool.size)
+	var errs []error
+	for res := range pool.Go() {
+		if res.isKeepAlive() {
+			continue
+		}
+		results[res.Index] = res
+		if res.Error != nil {
+			errs = append(errs, res.Error)
+		}
+	}
+	return results, errors.Join(errs...)
+}
+
+// ResultsAndErrors runs pool to completion and splits its outcomes into the
+// successful results, in task index order, and the errors keyed by the index
+// of the task that failed.
+func ResultsAndErrors[T any](pool *Pool[T]) ([]T, map[int]error) {
+	var outcomes []Optional[T]
+	for res := range pool.Go() {
+		if res.isKeepAlive() {
+			continue
+		}
+		outcomes = append(outcomes, res)
+	}
+	slices.SortFunc(outcomes, func(a, b Optional[T]) int {
+		return a.Index - b.Index
+	})
+	var results []T
+	errs := make(map[int]error)
+	for _, res := range outcomes {
+		if res.Error != nil {
+			errs[res.Index] = res.Error
+			continue
+		}
+		results = append(results, res.Result)
+	}
+	return results, errs
+}
+
+// Results runs the pool and returns its successful results, in task index
+// order, along with the errors of the failed tasks joined together, also in
+// index order. It saves unwrapping each Optional when only the values matter.
+func (g *Pool[T]) Results() ([]T, error) {
+	results, errs := ResultsAndErrors(g)
+	indices := slices.Sorted(maps.Keys(errs))
+	joined := make([]error, len(indices))
+	for n, i := range indices {
+		joined[n] = errs[i]
+	}
+	return results, errors.Join(joined...)
+}
+
+// WaitAll is Wait for when the outcome matters: it runs the pool to
+// completion and returns every result from its feed, in the order they were
+// delivered, along with the errors among them joined together. Unlike
+// CollectArray, tasks that delivered nothing leave no gap. Keep-alives are
+// left out.
+func (g *Pool[T]) WaitAll() ([]Optional[T], error) {
+	var all []Optional[T]
+	var errs []error
+	for res := range g.Go() {
+		if res.isKeepAlive() {
+			continue
+		}
+		all = append(all, res)
+		if res.Error != nil {
+			errs = append(errs, res.Error)
+		}
+	}
+	return all, errors.Join(errs...)
+}
+
+// AsProc returns a Proc that runs the pool and resolves to what Results
+// returns, so a pool can take part in a Proc pipeline. The Proc has the pool's
+// context.
+func (g *Pool[T]) AsProc() *Proc[[]T] {
+	return GoContext(g.ctx, func(ctx context.Context) ([]T, error) {
+		return g.Results()
+	})
+}
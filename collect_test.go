@@ -0,0 +1,191 @@
+package gogo
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGroupBy(t *testing.T) {
+	Convey("Given a pool of integers, GroupBy should bucket them by parity", t, func() {
+		pool := NewPool(3, 10, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		})
+		groups := GroupBy(pool, func(v int) string {
+			if v%2 == 0 {
+				return "even"
+			}
+			return "odd"
+		})
+		sort.Ints(groups["even"])
+		sort.Ints(groups["odd"])
+		So(groups, ShouldHaveLength, 2)
+		So(groups["even"], ShouldResemble, []int{0, 2, 4, 6, 8})
+		So(groups["odd"], ShouldResemble, []int{1, 3, 5, 7, 9})
+	})
+
+	Convey("Given failing tasks in an error pool, GroupBy should leave them to Errors", t, func() {
+		pool := NewErrorPool(3, 10, func(i int) func() (int, error) {
+			return func() (int, error) {
+				if i >= 8 {
+					return 0, errors.New("failed")
+				}
+				return i, nil
+			}
+		})
+		groups := GroupBy(pool, func(v int) bool {
+			return v%2 == 0
+		})
+		So(groups[true], ShouldHaveLength, 4)
+		So(groups[false], ShouldHaveLength, 4)
+		So(pool.Errors(), ShouldHaveLength, 2)
+	})
+}
+
+func TestCollectArray(t *testing.T) {
+	Convey("Given a pool, CollectArray should place each result at its task index", t, func() {
+		pool := NewPool(4, 20, func(i int) func() (int, error) {
+			return func() (int, error) {
+				if i == 7 {
+					return 0, errors.New("seven failed")
+				}
+				return i * i, nil
+			}
+		})
+		results, err := CollectArray(pool)
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldEqual, "seven failed")
+		So(results, ShouldHaveLength, 20)
+		for i, res := range results {
+			So(res.Index, ShouldEqual, i)
+			if i == 7 {
+				So(res.Error, ShouldNotBeNil)
+				continue
+			}
+			So(res.Result, ShouldEqual, i*i)
+		}
+	})
+}
+
+func TestResultsAndErrors(t *testing.T) {
+	Convey("Given a pool with mixed outcomes, ResultsAndErrors should split them by index", t, func() {
+		pool := NewPool(4, 10, func(i int) func() (int, error) {
+			return func() (int, error) {
+				if i%4 == 1 {
+					return 0, fmt.Errorf("task %d failed", i)
+				}
+				return i * 10, nil
+			}
+		})
+		results, errs := ResultsAndErrors(pool)
+		So(results, ShouldResemble, []int{0, 20, 30, 40, 60, 70, 80})
+		So(errs, ShouldHaveLength, 3)
+		So(errs[1].Error(), ShouldEqual, "task 1 failed")
+		So(errs[5].Error(), ShouldEqual, "task 5 failed")
+		So(errs[9].Error(), ShouldEqual, "task 9 failed")
+	})
+}
+
+func TestWaitAll(t *testing.T) {
+	Convey("Given a filtered pool with failures, WaitAll should return what was delivered and join the errors", t, func() {
+		pool := NewPool(3, 10, func(i int) func() (int, error) {
+			return func() (int, error) {
+				if i == 2 || i == 7 {
+					return 0, fmt.Errorf("task %d failed", i)
+				}
+				return i, nil
+			}
+		}).Filter(func(v int) bool {
+			return v < 5
+		})
+		all, err := pool.WaitAll()
+		So(all, ShouldHaveLength, 6)
+		var indices []int
+		for _, res := range all {
+			indices = append(indices, res.Index)
+		}
+		sort.Ints(indices)
+		So(indices, ShouldResemble, []int{0, 1, 2, 3, 4, 7})
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "task 2 failed")
+		So(err.Error(), ShouldContainSubstring, "task 7 failed")
+	})
+
+	Convey("Given a pool where everything succeeds, WaitAll should return a nil error", t, func() {
+		all, err := NewPool(2, 4, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		}).WaitAll()
+		So(all, ShouldHaveLength, 4)
+		So(err, ShouldBeNil)
+	})
+}
+
+func TestAsProc(t *testing.T) {
+	Convey("Given a pool turned into a Proc, its results should flow down the Proc chain", t, func() {
+		pool := NewPool(3, 5, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i + 1, nil
+			}
+		})
+		total, err := pool.AsProc().OnSuccess(func(values []int) ([]int, error) {
+			sum := 0
+			for _, v := range values {
+				sum += v
+			}
+			return []int{sum}, nil
+		}).Result()
+		So(err, ShouldBeNil)
+		So(total, ShouldResemble, []int{15})
+	})
+
+	Convey("Given a pool with failures, AsProc should keep the successes and join the errors", t, func() {
+		pool := NewPool(3, 6, func(i int) func() (int, error) {
+			return func() (int, error) {
+				if i%3 == 2 {
+					return 0, fmt.Errorf("task %d failed", i)
+				}
+				return i, nil
+			}
+		})
+		results, err := pool.AsProc().Result()
+		So(results, ShouldResemble, []int{0, 1, 3, 4})
+		So(err.Error(), ShouldEqual, "task 2 failed\ntask 5 failed")
+	})
+}
+
+func TestResults(t *testing.T) {
+	Convey("Given a pool whose tasks all succeed, Results should return the values in index order", t, func() {
+		pool := NewPool(4, 8, func(i int) func() (string, error) {
+			return func() (string, error) {
+				time.Sleep(time.Duration(8-i) * time.Millisecond)
+				return strconv.Itoa(i), nil
+			}
+		})
+		results, err := pool.Results()
+		So(err, ShouldBeNil)
+		So(results, ShouldResemble, []string{"0", "1", "2", "3", "4", "5", "6", "7"})
+	})
+
+	Convey("Given a pool with failures, Results should return the successes and every error", t, func() {
+		pool := NewPool(2, 4, func(i int) func() (int, error) {
+			return func() (int, error) {
+				if i%2 == 1 {
+					return 0, fmt.Errorf("task %d failed", i)
+				}
+				return i, nil
+			}
+		})
+		results, err := pool.Results()
+		So(results, ShouldResemble, []int{0, 2})
+		So(err.Error(), ShouldEqual, "task 1 failed\ntask 3 failed")
+	})
+}
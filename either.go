@@ -0,0 +1,39 @@
+package gogo
+
+import (
+	"time"
+)
+
+// Either holds one of two values: Left, or Right when IsRight is set.
+type Either[L any, R any] struct {
+	Left    L
+	Right   R
+	IsRight bool
+}
+
+// WithProgressTicker runs the pool and returns a channel carrying both its
+// results, on the Left, and the time every interval, on the Right, so a
+// consumer can update on whichever comes first. Ticks are dropped while the
+// consumer is busy, like a time.Ticker's. The channel closes after the last
+// result.
+func (g *Pool[T]) WithProgressTicker(interval time.Duration) <-chan Either[Optional[T], time.Time] {
+	feed := g.Go()
+	events := make(chan Either[Optional[T], time.Time])
+	go func() {
+		defer close(events)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case res, ok := <-feed:
+				if !ok {
+					return
+				}
+				events <- Either[Optional[T], time.Time]{Left: res}
+			case now := <-ticker.C:
+				events <- Either[Optional[T], time.Time]{Right: now, IsRight: true}
+			}
+		}
+	}()
+	return events
+}
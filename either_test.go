@@ -0,0 +1,31 @@
+package gogo
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestProgressTicker(t *testing.T) {
+	Convey("Given a slow pool with a progress ticker, both results and ticks should arrive", t, func() {
+		pool := NewPool(1, 5, func(i int) func() (int, error) {
+			return func() (int, error) {
+				time.Sleep(30 * time.Millisecond)
+				return i, nil
+			}
+		})
+		var results []int
+		ticks := 0
+		for event := range pool.WithProgressTicker(10 * time.Millisecond) {
+			if event.IsRight {
+				So(event.Right.IsZero(), ShouldBeFalse)
+				ticks++
+				continue
+			}
+			results = append(results, event.Left.Result)
+		}
+		So(results, ShouldResemble, []int{0, 1, 2, 3, 4})
+		So(ticks, ShouldBeGreaterThan, 3)
+	})
+}
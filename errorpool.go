@@ -0,0 +1,64 @@
+package gogo
+
+import (
+	"context"
+)
+
+// NewErrorPool is like NewPool, but the pool also keeps every error its tasks
+// return so they can be read back with Errors once it has finished. By default
+// failed results are still sent on the feed as well, so a consumer that both
+// ranges the feed and calls Errors sees each failure twice; use
+// WithSuccessOnlyFeed to keep errors off the feed.
+func NewErrorPool[T any](concurrency int, size int, fn func(i int) func() (T, error)) *Pool[T] {
+	pool := NewPool(concurrency, size, fn)
+	pool.collectErrors = true
+	return pool
+}
+
+// NewBoundedErrorPool is like NewErrorPool, but tasks are handed a context
+// derived from ctx, as with NewPoolContext, and the pool only keeps the first
+// maxErrors errors. Later ones are counted by DroppedErrorCount instead, so a
+// huge pool where everything fails doesn't hold on to every error. The tasks
+// all run either way.
+func NewBoundedErrorPool[T any](ctx context.Context, concurrency int, size int, maxErrors int, fn func(i int) func(ctx context.Context) (T, error)) *Pool[T] {
+	pool := NewPoolContext(ctx, concurrency, size, fn)
+	pool.collectErrors = true
+	pool.errorsCap = max(maxErrors, 1)
+	return pool
+}
+
+// DroppedErrorCount returns how many errors a pool from NewBoundedErrorPool
+// has failed with beyond the ones Errors returns.
+func (g *Pool[T]) DroppedErrorCount() int {
+	g.errorsMu.Lock()
+	defer g.errorsMu.Unlock()
+	return g.droppedErrors
+}
+
+// WithSuccessOnlyFeed keeps failed results off the feed, leaving Errors as the
+// single place to find them. The feed then only carries successes. Calling it
+// turns on error collection if the pool was not created with NewErrorPool.
+func (g *Pool[T]) WithSuccessOnlyFeed() *Pool[T] {
+	g.mustNotBeStarted()
+	g.collectErrors = true
+	g.successOnly = true
+	return g
+}
+
+// Errors returns the errors collected so far, in the order tasks failed. It is
+// only complete once the pool has finished.
+func (g *Pool[T]) Errors() []error {
+	g.errorsMu.Lock()
+	defer g.errorsMu.Unlock()
+	return append([]error(nil), g.errors...)
+}
+
+func (g *Pool[T]) collectError(err error) {
+	g.errorsMu.Lock()
+	if g.errorsCap > 0 && len(g.errors) == g.errorsCap {
+		g.droppedErrors++
+	} else {
+		g.errors = append(g.errors, err)
+	}
+	g.errorsMu.Unlock()
+}
@@ -0,0 +1,94 @@
+package gogo
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func failOdd(i int) func() (int, error) {
+	return func() (int, error) {
+		if i%2 == 1 {
+			return 0, errors.New("odd")
+		}
+		return i, nil
+	}
+}
+
+func TestErrorPool(t *testing.T) {
+	Convey("Given an error pool, Errors should collect every failure and the feed should still carry them", t, func() {
+		pool := NewErrorPool(3, 10, failOdd)
+		var failed int
+		for res := range pool.Go() {
+			if res.Error != nil {
+				failed++
+			}
+		}
+		So(failed, ShouldEqual, 5)
+		So(pool.Errors(), ShouldHaveLength, 5)
+	})
+
+	Convey("Given an error pool with a success only feed, failures should only be in Errors", t, func() {
+		pool := NewErrorPool(3, 10, failOdd).WithSuccessOnlyFeed()
+		var results []int
+		for res := range pool.Go() {
+			So(res.Error, ShouldBeNil)
+			results = append(results, res.Result)
+		}
+		So(results, ShouldHaveLength, 5)
+		So(pool.Errors(), ShouldHaveLength, 5)
+	})
+
+	Convey("Given an ordered success only feed, dropped failures should not hold back later results", t, func() {
+		pool := NewErrorPool(3, 10, failOdd).WithSuccessOnlyFeed().WithReorderWindow(10)
+		var results []int
+		for res := range pool.Go() {
+			results = append(results, res.Result)
+		}
+		So(results, ShouldResemble, []int{0, 2, 4, 6, 8})
+	})
+}
+
+func TestErrorPoolPanics(t *testing.T) {
+	Convey("Given an error pool with a panicking task, the panic should be collected as an error", t, func() {
+		pool := NewErrorPool(2, 5, func(i int) func() (int, error) {
+			return func() (int, error) {
+				if i == 3 {
+					panic("boom")
+				}
+				return i, nil
+			}
+		})
+		count := 0
+		for range pool.Go() {
+			count++
+		}
+		So(count, ShouldEqual, 5)
+		So(pool.Errors(), ShouldHaveLength, 1)
+		So(pool.Errors()[0].Error(), ShouldEqual, "gogo: task 3 panicked: boom")
+	})
+
+	Convey("Given a bounded error pool, it should keep only the first errors and count the rest", t, func() {
+		var ran atomic.Int32
+		pool := NewBoundedErrorPool(context.Background(), 4, 100, 10, func(i int) func(ctx context.Context) (int, error) {
+			return func(ctx context.Context) (int, error) {
+				ran.Add(1)
+				return 0, errors.New("failed")
+			}
+		}).WithSuccessOnlyFeed()
+		pool.Wait()
+		So(ran.Load(), ShouldEqual, 100)
+		So(pool.Errors(), ShouldHaveLength, 10)
+		So(pool.DroppedErrorCount(), ShouldEqual, 90)
+	})
+
+	Convey("Given an unbounded error pool, nothing should be dropped", t, func() {
+		pool := NewErrorPool(2, 10, failOdd)
+		pool.Wait()
+		So(pool.Errors(), ShouldHaveLength, 5)
+		So(pool.DroppedErrorCount(), ShouldEqual, 0)
+	})
+}
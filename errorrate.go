@@ -0,0 +1,84 @@
+package gogo
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// errorRateMonitor tracks the outcome of the last window results in a ring
+// buffer and calls onTrip the first time the failure rate exceeds threshold.
+type errorRateMonitor struct {
+	mu        sync.Mutex
+	outcomes  []bool // true for failures
+	next      int
+	seen      int
+	failures  int
+	threshold float64
+	onTrip    func()
+	tripped   bool
+}
+
+func (m *errorRateMonitor) observe(failed bool) {
+	m.mu.Lock()
+	if m.seen == len(m.outcomes) && m.outcomes[m.next] {
+		m.failures--
+	}
+	m.outcomes[m.next] = failed
+	if failed {
+		m.failures++
+	}
+	m.next = (m.next + 1) % len(m.outcomes)
+	if m.seen < len(m.outcomes) {
+		m.seen++
+	}
+	trip := !m.tripped && m.seen == len(m.outcomes) &&
+		float64(m.failures)/float64(m.seen) > m.threshold
+	if trip {
+		m.tripped = true
+	}
+	m.mu.Unlock()
+
+	if trip {
+		m.onTrip()
+	}
+}
+
+// WithErrorRateThreshold calls onTrip once the failure rate over the last
+// window results exceeds threshold (a fraction between 0 and 1). The rate is
+// only evaluated once a full window of results has been seen, and onTrip fires
+// at most once, from the goroutine of the task that tripped it. Cancelling the
+// pool from onTrip is safe.
+func (g *Pool[T]) WithErrorRateThreshold(window int, threshold float64, onTrip func()) *Pool[T] {
+	g.mustNotBeStarted()
+	if window < 1 {
+		window = 1
+	}
+	monitor := &errorRateMonitor{
+		outcomes:  make([]bool, window),
+		threshold: threshold,
+		onTrip:    onTrip,
+	}
+	g.observers = append(g.observers, func(res Optional[T]) {
+		monitor.observe(res.Error != nil)
+	})
+	return g
+}
+
+// WithMaxErrors cancels the pool, with ErrMaxErrorsReached as the cause, once
+// n tasks have failed. Tasks already running when the n-th error arrives still
+// finish and deliver their results, so a few more errors than n may reach the
+// feed. The limit counts failures, not tasks started: each worker works
+// through its own share of the tasks, so a worker whose tasks succeed may run
+// well ahead of the ones that fail before the pool is cancelled. An n of zero
+// or less cancels on the first failure.
+func (g *Pool[T]) WithMaxErrors(n int) *Pool[T] {
+	g.mustNotBeStarted()
+	n = max(n, 1)
+	failures := &atomic.Int64{}
+	g.observers = append(g.observers, func(res Optional[T]) {
+		if res.Error != nil && failures.Add(1) == int64(n) {
+			g.cancelWith(CancelMaxErrors)
+		}
+	})
+	return g
+}
@@ -0,0 +1,133 @@
+package gogo
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestErrorRateThreshold(t *testing.T) {
+	Convey("Given a burst of failures, WithErrorRateThreshold should trip once", t, func() {
+		var trips int32
+		pool := NewPool(1, 30, func(i int) func() (int, error) {
+			return func() (int, error) {
+				if i >= 10 {
+					return 0, errors.New("backend down")
+				}
+				return i, nil
+			}
+		}).WithErrorRateThreshold(10, 0.5, func() {
+			atomic.AddInt32(&trips, 1)
+		})
+		pool.Wait()
+		So(atomic.LoadInt32(&trips), ShouldEqual, 1)
+	})
+
+	Convey("Given occasional failures below the threshold, WithErrorRateThreshold should not trip", t, func() {
+		var trips int32
+		pool := NewPool(2, 30, func(i int) func() (int, error) {
+			return func() (int, error) {
+				if i%5 == 0 {
+					return 0, errors.New("flaky")
+				}
+				return i, nil
+			}
+		}).WithErrorRateThreshold(10, 0.5, func() {
+			atomic.AddInt32(&trips, 1)
+		})
+		pool.Wait()
+		So(atomic.LoadInt32(&trips), ShouldEqual, 0)
+	})
+
+	Convey("Given a tripped monitor, onTrip can cancel the pool", t, func() {
+		var ran int32
+		var pool *Pool[int]
+		pool = NewPool(1, 100, func(i int) func() (int, error) {
+			return func() (int, error) {
+				atomic.AddInt32(&ran, 1)
+				return 0, errors.New("always fails")
+			}
+		}).WithErrorRateThreshold(5, 0.5, func() {
+			pool.Cancel()
+		})
+		pool.Wait()
+		So(atomic.LoadInt32(&ran), ShouldBeLessThan, 100)
+	})
+}
+
+func TestMaxErrors(t *testing.T) {
+	Convey("Given a pool with a threshold of 3 errors, it should cancel after the third failure", t, func() {
+		var ran int32
+		pool := NewPool(1, 20, func(i int) func() (int, error) {
+			return func() (int, error) {
+				atomic.AddInt32(&ran, 1)
+				return 0, errors.New("failed")
+			}
+		}).WithMaxErrors(3)
+		var errs int
+		for res := range pool.Go() {
+			if res.Error != nil {
+				errs++
+			}
+		}
+		So(atomic.LoadInt32(&ran), ShouldEqual, 3)
+		So(errs, ShouldEqual, 3)
+	})
+
+	Convey("Given concurrent failures, WithMaxErrors should cancel once and stop dispatch early", t, func() {
+		var ran int32
+		pool := NewPool(4, 100, func(i int) func() (int, error) {
+			return func() (int, error) {
+				atomic.AddInt32(&ran, 1)
+				if i%2 == 0 {
+					return 0, errors.New("failed")
+				}
+				return i, nil
+			}
+		}).WithMaxErrors(3)
+		var errs int
+		for res := range pool.Go() {
+			if res.Error != nil {
+				errs++
+			}
+		}
+		// Workers with only odd tasks never fail and may run all 50 of them,
+		// but no more than one task per worker can fail past the third
+		So(errs, ShouldBeBetweenOrEqual, 3, 3+3)
+		So(atomic.LoadInt32(&ran), ShouldBeLessThanOrEqualTo, 50+3+3)
+		So(pool.ctx.Err(), ShouldNotBeNil)
+	})
+
+	Convey("Given fewer failures than the threshold, the pool should run to completion", t, func() {
+		pool := NewPool(2, 10, func(i int) func() (int, error) {
+			return func() (int, error) {
+				if i < 2 {
+					return 0, errors.New("failed")
+				}
+				return i, nil
+			}
+		}).WithMaxErrors(3)
+		count := 0
+		for range pool.Go() {
+			count++
+		}
+		So(count, ShouldEqual, 10)
+		So(pool.ctx.Err(), ShouldBeNil)
+	})
+
+	Convey("Given a threshold of zero, the first failure should cancel the pool", t, func() {
+		pool := NewPool(1, 10, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return 0, errors.New("failed")
+			}
+		}).WithMaxErrors(0)
+		count := 0
+		for range pool.Go() {
+			count++
+		}
+		So(count, ShouldEqual, 1)
+		So(pool.CancelReason(), ShouldEqual, CancelMaxErrors)
+	})
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -55,7 +56,7 @@ func ConcurrentGoroutinePoolsWithConcurrentFeed() {
 	// Or listen to a feed of results (concurrent safe)
 	feed := pool.Go()
 	// read the feed concurrently
-	gogo.GoVoid[struct{}](func() {
+	gogo.Run(context.Background(), func(context.Context) {
 		for res := range feed {
 			if res.Error == nil {
 				doc, err := goquery.NewDocumentFromReader(res.Result.Body)
@@ -0,0 +1,17 @@
+package gogo
+
+// Filter keeps successful results for which keep reports false off the feed,
+// rather than delivering them as ErrFilterRejected errors. Failed results are
+// delivered as usual. Calling Filter again adds another condition, and a
+// result has to pass them all.
+func (g *Pool[T]) Filter(keep func(T) bool) *Pool[T] {
+	g.mustNotBeStarted()
+	if prev := g.keep; prev != nil {
+		g.keep = func(res T) bool {
+			return prev(res) && keep(res)
+		}
+		return g
+	}
+	g.keep = keep
+	return g
+}
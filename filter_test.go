@@ -0,0 +1,81 @@
+package gogo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFilter(t *testing.T) {
+	Convey("Given a Proc whose value is filtered out, IsFiltered should report it", t, func() {
+		isEven := func(v int) bool { return v%2 == 0 }
+		_, err := Just(context.Background(), 3).Filter(isEven).Result()
+		So(IsFiltered(err), ShouldBeTrue)
+		So(IsFiltered(fmt.Errorf("stage 2: %w", err)), ShouldBeTrue)
+
+		res, err := Just(context.Background(), 4).Filter(isEven).Result()
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, 4)
+	})
+
+	Convey("Given a filtered pool, rejected results should not reach the feed", t, func() {
+		pool := NewPool(3, 20, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		}).Filter(func(v int) bool {
+			return v%2 == 0
+		}).Filter(func(v int) bool {
+			return v < 10
+		})
+		var got []int
+		for res := range pool.Go() {
+			got = append(got, res.Result)
+		}
+		So(got, ShouldHaveLength, 5)
+		for _, v := range got {
+			So(v%2 == 0 && v < 10, ShouldBeTrue)
+		}
+	})
+
+	Convey("Given a filtered pool, failed results should pass through untouched", t, func() {
+		errBoom := errors.New("boom")
+		var checked []int
+		pool := NewPool(1, 6, func(i int) func() (int, error) {
+			return func() (int, error) {
+				if i%2 == 1 {
+					return i, errBoom
+				}
+				return i, nil
+			}
+		}).Filter(func(v int) bool {
+			checked = append(checked, v)
+			return false
+		})
+		var errs []error
+		for res := range pool.Go() {
+			errs = append(errs, res.Error)
+		}
+		So(errs, ShouldResemble, []error{errBoom, errBoom, errBoom})
+		// keep only ever sees successes
+		So(checked, ShouldResemble, []int{0, 2, 4})
+	})
+
+	Convey("Given a filtered pool with ordered results, the kept results should stay in order", t, func() {
+		pool := NewPool(4, 10, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		}).WithOrderedResults().Filter(func(v int) bool {
+			return v%3 != 0
+		})
+		var got []int
+		for res := range pool.Go() {
+			got = append(got, res.Result)
+		}
+		So(got, ShouldResemble, []int{1, 2, 4, 5, 7, 8})
+	})
+}
@@ -0,0 +1,60 @@
+package gogo
+
+import (
+	"context"
+	"errors"
+)
+
+var errNoProcs = errors.New("gogo: no Procs to take a success from")
+
+// FirstSuccess returns a Proc that resolves to the result of the first of
+// procs to succeed. If they all fail, it fails with their errors joined, in
+// the order of procs, and if ctx is done first, with ctx's error. Once one
+// succeeds it stops waiting for the others, but they run under their own
+// contexts and keep going; use FirstSuccessOf to have them cancelled.
+func FirstSuccess[T any](ctx context.Context, procs ...*Proc[T]) *Proc[T] {
+	return GoContext(ctx, func(ctx context.Context) (T, error) {
+		var zero T
+		if len(procs) == 0 {
+			return zero, errNoProcs
+		}
+		waitCtx, cancel := context.WithCancel(ctx)
+		defer cancel() // Stop waiting for the losers
+		results := make(chan Optional[T], len(procs))
+		for i, p := range procs {
+			go func() {
+				res, err := p.ResultContext(waitCtx)
+				results <- Optional[T]{Result: res, Error: err, Index: i}
+			}()
+		}
+		errs := make([]error, len(procs))
+		for range procs {
+			res := <-results
+			if res.Error == nil {
+				return res.Result, nil
+			}
+			errs[res.Index] = res.Error
+		}
+		if err := ctx.Err(); err != nil {
+			return zero, err
+		}
+		return zero, errors.Join(errs...)
+	})
+}
+
+// FirstSuccessOf runs each of fns as a Proc and resolves like FirstSuccess.
+// They run under a context derived from ctx which is cancelled once the
+// returned Proc resolves, so the losers are told to stop.
+func FirstSuccessOf[T any](ctx context.Context, fns ...func(ctx context.Context) (T, error)) *Proc[T] {
+	raceCtx, cancel := context.WithCancel(ctx)
+	procs := make([]*Proc[T], len(fns))
+	for i, fn := range fns {
+		procs[i] = GoContext(raceCtx, fn)
+	}
+	first := FirstSuccess(ctx, procs...)
+	go func() {
+		first.Wait()
+		cancel()
+	}()
+	return first
+}
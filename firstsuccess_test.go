@@ -0,0 +1,78 @@
+package gogo
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFirstSuccess(t *testing.T) {
+	Convey("Given a fast failure and a slower success, FirstSuccess should take the success", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		failing := Go(func() (string, error) {
+			return "", errors.New("backend a down")
+		})
+		slow := Go(func() (string, error) {
+			time.Sleep(20 * time.Millisecond)
+			return "b", nil
+		})
+		hanging := Lazy(ctx, func(ctx context.Context) (string, error) {
+			<-ctx.Done()
+			return "", ctx.Err()
+		})
+		start := time.Now()
+		res, err := FirstSuccess(ctx, failing, slow, hanging).Result()
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, "b")
+		So(time.Since(start), ShouldBeLessThan, time.Second)
+	})
+
+	Convey("Given Procs that all fail, FirstSuccess should join their errors in order", t, func() {
+		ctx := context.Background()
+		errA, errB := errors.New("a"), errors.New("b")
+		_, err := FirstSuccess(ctx, Fail[int](ctx, errA), Fail[int](ctx, errB)).Result()
+		So(err, ShouldWrap, errA)
+		So(err, ShouldWrap, errB)
+		So(err.Error(), ShouldEqual, "a\nb")
+
+		_, err = FirstSuccess[int](ctx).Result()
+		So(err, ShouldNotBeNil)
+	})
+
+	Convey("Given a context done before any success, FirstSuccess should fail with its error", t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		never := Lazy(ctx, func(ctx context.Context) (int, error) {
+			<-ctx.Done()
+			return 0, errors.New("never succeeds")
+		})
+		_, err := FirstSuccess(ctx, never).Result()
+		So(err, ShouldEqual, context.DeadlineExceeded)
+	})
+
+	Convey("Given functions racing with FirstSuccessOf, the losers should be cancelled", t, func() {
+		loserDone := make(chan error, 1)
+		res, err := FirstSuccessOf(context.Background(),
+			func(ctx context.Context) (string, error) {
+				return "fast", nil
+			},
+			func(ctx context.Context) (string, error) {
+				<-ctx.Done()
+				loserDone <- ctx.Err()
+				return "", ctx.Err()
+			},
+		).Result()
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, "fast")
+		select {
+		case err := <-loserDone:
+			So(err, ShouldEqual, context.Canceled)
+		case <-time.After(time.Second):
+			So("loser was never cancelled", ShouldBeEmpty)
+		}
+	})
+}
@@ -1,6 +1,6 @@
 module github.com/stcrestrada/gogo
 
-go 1.22.3
+go 1.23
 
 require (
 	github.com/PuerkitoBio/goquery v1.9.2
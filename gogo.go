@@ -1,23 +1,63 @@
 package gogo
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
+// errSkip is returned by a pool task that has no result to deliver.
+var errSkip = errors.New("gogo: skip result")
+
+// Optional is the outcome of a task: its result, or the error it failed with.
 type Optional[T any] struct {
 	Result T
 	Error  error
+	// Index is the position of the task within its pool. It is always zero for
+	// results that do not come from a pool.
+	Index int
+	// Attempts is how many times the task was run, more than once if
+	// WithRetries retried it. Like Index, it is zero for results that do not
+	// come from a pool.
+	Attempts int
 }
 
 type Proc[T any] struct {
-	fn     func() (T, error)
-	result *Optional[T]
+	ctx    context.Context
+	fn     func(ctx context.Context) (T, error)
+	result atomic.Pointer[Optional[T]]
 	once   sync.Once
 	wg     sync.WaitGroup
+
+	// Derived Procs are counted rather than kept, for WaitDerived. A Proc is
+	// busy until it has resolved and every Proc derived from it is idle.
+	derivedMu sync.Mutex
+	parent    *Proc[T]      // Told when p goes from busy to idle and back
+	busy      int           // How many Procs derived from p are busy
+	settled   bool          // Whether p has resolved, for its parent
+	idle      chan struct{} // Closed when busy drops to zero, if set
+
+	claim func() error // Set by GoWithCleanup, see Use
 }
 
+// Done reports whether the result is available, without waiting for it.
 func (p *Proc[T]) Done() bool {
-	return p.result != nil
+	return p.result.Load() != nil
+}
+
+// TryResult returns the result and true if it is available, and the zero
+// value and false if not. It never waits, and unlike Result it doesn't start a
+// Proc from Lazy.
+func (p *Proc[T]) TryResult() (T, error, bool) {
+	result := p.result.Load()
+	if result == nil {
+		var zero T
+		return zero, nil, false
+	}
+	return result.Result, result.Error, true
 }
 
 // Blocking
@@ -26,17 +66,18 @@ func (p *Proc[T]) Go() (T, error) {
 		p.wg.Add(1)
 		resultsChan := make(chan *Optional[T])
 		go func() {
-			res, err := p.fn()
+			res, err := p.fn(p.Context())
 			resultsChan <- &Optional[T]{
 				Result: res,
 				Error:  err,
 			}
 			p.wg.Done()
 		}()
-		result := <-resultsChan
-		p.result = result
+		p.result.Store(<-resultsChan)
+		p.settle()
 	})
-	return p.result.Result, p.result.Error
+	result := p.result.Load()
+	return result.Result, result.Error
 }
 
 func (p *Proc[T]) Wait() {
@@ -46,7 +87,7 @@ func (p *Proc[T]) Wait() {
 
 // Wrap a simple function
 func GoVoid[T any](f func()) *Proc[T] {
-	wrapper := func() (T, error) {
+	wrapper := func(context.Context) (T, error) {
 		f()
 		var t T
 		return t, nil
@@ -59,76 +100,677 @@ func GoVoid[T any](f func()) *Proc[T] {
 	return proc
 }
 
+// Run runs f in another goroutine, handing it ctx. It is GoVoid for the
+// common case where there is no result, without a type parameter to pick.
+func Run(ctx context.Context, f func(ctx context.Context)) *Proc[struct{}] {
+	return GoContext(ctx, func(ctx context.Context) (struct{}, error) {
+		f(ctx)
+		return struct{}{}, nil
+	})
+}
+
 func (p *Proc[T]) Result() (T, error) {
 	return p.Go()
 }
 
+// Await waits for the Proc like Result, but only returns its error, for Procs
+// like those from Run whose value doesn't matter.
+func (p *Proc[T]) Await() error {
+	_, err := p.Result()
+	return err
+}
+
+// ResultContext is like Result, but stops waiting once ctx is done and returns
+// ctx.Err() instead. The Proc itself keeps running, so another caller can
+// still wait for its result.
+func (p *Proc[T]) ResultContext(ctx context.Context) (T, error) {
+	if err := p.WaitContext(ctx); err != nil {
+		var zero T
+		return zero, err
+	}
+	return p.Result()
+}
+
+// WaitContext is like Wait, but stops waiting once ctx is done and returns
+// ctx.Err(). It returns nil once the Proc has finished, whether or not it
+// failed.
+func (p *Proc[T]) WaitContext(ctx context.Context) error {
+	if p.Done() {
+		return nil
+	}
+	done := make(chan struct{})
+	go func() {
+		p.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func Go[T any](fn func() (T, error)) *Proc[T] {
+	return GoContext(context.Background(), func(context.Context) (T, error) {
+		return fn()
+	})
+}
+
+// GoContext is like Go, but fn is handed ctx, which the Proc keeps as its
+// Context.
+func GoContext[T any](ctx context.Context, fn func(ctx context.Context) (T, error)) *Proc[T] {
 	proc := &Proc[T]{
-		fn: fn,
+		ctx: ctx,
+		fn:  fn,
 	}
 	go proc.Go()
 	return proc
 }
 
+// Lazy is like GoContext, but doesn't start fn. It runs on the first Go,
+// Result or Wait, so a Proc can be built up front and only run if needed.
+func Lazy[T any](ctx context.Context, fn func(ctx context.Context) (T, error)) *Proc[T] {
+	return &Proc[T]{
+		ctx: ctx,
+		fn:  fn,
+	}
+}
+
+// Context returns the context the Proc was created with, or
+// context.Background if it was created without one.
+func (p *Proc[T]) Context() context.Context {
+	if p.ctx == nil {
+		return context.Background()
+	}
+	return p.ctx
+}
+
+// WithContext returns a new Proc that runs the same function as p, but under
+// ctx. p itself is unaffected: Go and GoContext already started it when it was
+// created. The new Proc has not run yet and runs on its first Go, Result or
+// Wait, which makes it a way to retry an operation under a fresh deadline. A
+// Proc that was already resolved, like one from Just, stays resolved to the
+// same result.
+func (p *Proc[T]) WithContext(ctx context.Context) *Proc[T] {
+	if p.fn == nil {
+		res, err := p.Result()
+		return resolved(ctx, res, err)
+	}
+	return Lazy(ctx, p.fn)
+}
+
 type Pool[T any] struct {
-	concurrency int
-	size        int
-	makeFn      func(i int) func() (T, error)
-	feed        chan Optional[T] // Sized to size
-	wg          *sync.WaitGroup  // Sized to 1 always
-	closeOnce   sync.Once
-	startOnce   sync.Once
-	closed      bool
+	ctx           context.Context
+	cancel        context.CancelCauseFunc
+	concurrency   int
+	size          int
+	makeFn        func(i int) func(ctx context.Context) (T, error)
+	makeMu        sync.Mutex
+	observers     []func(Optional[T])
+	limiter       *RateLimiter
+	reorder       *reorderBuffer[T]
+	restarts      *atomic.Int64
+	onPanic       func(i int, recovered any)
+	retries       *retryPolicy
+	throttle      *throttle
+	budget        time.Duration
+	collectErrors bool
+	successOnly   bool
+	synchronous   bool
+	inOrder       bool // Start tasks strictly by index, see dispatch
+	errorsMu      sync.Mutex
+	errors        []error
+	errorsCap     int // How many errors to keep, if positive
+	droppedErrors int
+	deadline      time.Time // Set from budget when the pool starts
+	onProgress    func(completed, total int)
+	keepAlive     *keepAlive[T]
+	shutdown      *gracefulShutdown
+	memory        *budgetedFeed[T]
+	startInterval time.Duration
+	keep          func(T) bool    // Set by Filter
+	parent        context.Context // What the pool's context was created from
+	cancelReason  atomic.Int32
+	resumeFrom    map[int]bool // Tasks completed by an earlier run
+	stats         poolCounters
+	latencies     *latencySampler
+	progressMu    sync.Mutex
+	completed     int
+	shared        *ConcurrencyLimiter
+	onClose       []func()          // Called as the feed closes
+	feed          chan Optional[T]  // Sized to size
+	ptrFeed       chan *Optional[T] // Replaces feed when set
+	ptrOnce       sync.Once
+	wg            *sync.WaitGroup // Sized to 1 always
+	closeOnce     sync.Once
+	startOnce     sync.Once
+	started       atomic.Bool
+	consumed      atomic.Bool // Set once Go hands out the feed
+	closed        bool
 }
 
 func (g *Pool[T]) close() {
 	g.closeOnce.Do(func() {
 		g.closed = true
+		for _, f := range g.onClose {
+			f()
+		}
+		if g.ptrFeed != nil {
+			close(g.ptrFeed)
+		}
+		if g.memory != nil {
+			// The feed closes once the staged results have been forwarded
+			close(g.memory.staged)
+			return
+		}
 		close(g.feed)
 		g.wg.Done()
 	})
 }
 
 func (g *Pool[T]) Go() chan Optional[T] {
+	g.consumed.Store(true)
+	g.start()
+	return g.feed
+}
+
+// start runs the pool, once, without handing out its feed.
+func (g *Pool[T]) start() {
 	// Close the ability to use the rest of it
+	g.started.Store(true)
 	go g.startOnce.Do(func() {
-		var wg = &sync.WaitGroup{}
-		wg.Add(g.size)
-		guard := make(chan struct{}, g.concurrency)
+		if g.shared = limiterFromContext(g.ctx); g.shared != nil {
+			defer g.shared.sem.watch(g.ctx)()
+		}
+		if g.budget > 0 {
+			g.deadline = time.Now().Add(g.budget)
+		}
+		if g.memory != nil {
+			go g.memory.forward(g.feed, g.wg.Done)
+		}
+		stopKeepAlive := func() {}
+		if g.keepAlive != nil {
+			stopKeepAlive = g.keepAlive.run(g.trySend)
+		}
 		// Execute the work here
-		for i := 0; i < g.size; i++ {
-			guard <- struct{}{}
-			fn := g.makeFn(i)
-			go func() {
-				res, err := fn()
-				g.feed <- Optional[T]{
-					Result: res,
-					Error:  err,
+		if g.synchronous {
+			for i := 0; i < g.size && !g.stopping(); i++ {
+				if g.resumed(i) {
+					continue
 				}
-				<-guard
-				wg.Done()
-			}()
-
+				fn := g.makeFn(i)
+				for g.run(i, fn) != nil {
+				}
+			}
+		} else if !g.stopping() {
+			// A pool whose context is already done, like a chain built on a
+			// cancelled context, closes straight away without any workers
+			g.dispatch()
+		}
+		stopKeepAlive()
+		if g.shutdown != nil {
+			g.shutdown.finish()
+		}
+		if g.reorder != nil {
+			g.reorder.flush(g.send)
 		}
-		wg.Wait()
 		g.close() // Make sure we close it
 	})
-	return g.feed
 }
 
+type poolTask[T any] struct {
+	i  int
+	fn func(ctx context.Context) (T, error)
+}
+
+// dispatch runs the tasks on a fixed set of concurrency workers and waits for
+// them to finish. Each worker has its own queue of task indices and steals
+// from the others once it runs dry, so workers don't contend over a single
+// source of tasks. A pool that must start its tasks strictly by index, like a
+// priority pool, has all its workers share a single queue instead.
+func (g *Pool[T]) dispatch() {
+	queues := newWorkQueues(g.concurrency, g.size)
+	if g.inOrder {
+		queues = newWorkQueues(1, g.size)
+	}
+	workers := &sync.WaitGroup{}
+	workers.Add(g.concurrency)
+	for w := 0; w < g.concurrency; w++ {
+		go g.worker(w, queues, workers, nil)
+	}
+	workers.Wait()
+}
+
+// worker runs tasks until there are none left, starting with pending if it is
+// set. If a task panics and restarts are left, a replacement worker takes over
+// the same queue, starting with that same task.
+func (g *Pool[T]) worker(w int, queues workQueues, workers *sync.WaitGroup, pending *poolTask[T]) {
+	if pending == nil && g.startInterval > 0 {
+		g.stagger(w)
+	}
+	victim := w
+	for {
+		var task poolTask[T]
+		if pending != nil {
+			task, pending = *pending, nil
+		} else {
+			i, ok := queues.next(w%len(queues), &victim)
+			if !ok || g.stopping() {
+				break
+			}
+			if g.resumed(i) {
+				continue
+			}
+			task = poolTask[T]{i: i, fn: g.task(i)}
+		}
+		if recovered := g.run(task.i, task.fn); recovered != nil {
+			go g.worker(w, queues, workers, &task)
+			return
+		}
+	}
+	workers.Done()
+}
+
+// task builds task i. Workers call it concurrently, so it serializes the calls
+// to makeFn, which isn't required to be safe for concurrent use.
+func (g *Pool[T]) task(i int) func(ctx context.Context) (T, error) {
+	g.makeMu.Lock()
+	defer g.makeMu.Unlock()
+	return g.makeFn(i)
+}
+
+// run runs task i and delivers its result. A task that panics fails with an
+// error saying so, unless the pool has a worker restart left: then nothing is
+// delivered and the recovered value is returned instead.
+func (g *Pool[T]) run(i int, fn func(ctx context.Context) (T, error)) any {
+	g.stats.submitted.Add(1)
+	if g.limiter != nil {
+		g.limiter.Wait(g.ctx)
+	}
+	if g.throttle != nil {
+		g.throttle.wait(g.ctx)
+	}
+	if g.shared != nil && !g.shared.sem.acquire(g.ctx) {
+		return nil
+	}
+	// Don't launch once cancelled
+	if g.stopping() {
+		g.releaseShared()
+		return nil
+	}
+	g.stats.started.Add(1)
+	var res T
+	var err error
+	var recovered any
+	attempts := 0
+	for {
+		attempts++
+		ctx, cancel := g.taskContext(i)
+		var start time.Time
+		if g.latencies != nil {
+			start = time.Now()
+		}
+		res, err, recovered = g.call(i, ctx, fn)
+		if g.latencies != nil {
+			g.latencies.record(time.Since(start))
+		}
+		cancel()
+		if recovered != nil || !g.retry(attempts, err) {
+			break
+		}
+	}
+	g.releaseShared()
+	if recovered != nil {
+		if g.restarts != nil && g.restarts.Add(-1) >= 0 {
+			// The task runs again and is counted afresh, so each task is
+			// only counted once
+			g.stats.submitted.Add(-1)
+			g.stats.started.Add(-1)
+			return recovered
+		}
+		err = &PanicError{Index: i, Value: recovered}
+	}
+	if err != nil && g.ctx.Err() != nil &&
+		(errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+		err = &CancelError{Reason: g.CancelReason(), Cause: context.Cause(g.ctx), Err: err}
+	}
+	switch {
+	case err == nil:
+		g.stats.succeeded.Add(1)
+	case err == errSkip:
+		g.stats.finished.Add(1)
+	default:
+		g.stats.failed.Add(1)
+	}
+	defer g.progress()
+
+	if err == errSkip || err == nil && g.keep != nil && !g.keep(res) {
+		g.skip(i)
+		return nil
+	}
+	result := Optional[T]{
+		Result:   res,
+		Error:    err,
+		Index:    i,
+		Attempts: attempts,
+	}
+	for _, observe := range g.observers {
+		observe(result)
+	}
+	if err != nil && g.collectErrors {
+		g.collectError(err)
+		if g.successOnly {
+			g.skip(i)
+			return nil
+		}
+	}
+	g.emit(i, result)
+	return nil
+}
+
+// releaseShared frees the slot a task held in a shared limiter, if any.
+func (g *Pool[T]) releaseShared() {
+	if g.shared != nil {
+		g.shared.sem.release()
+	}
+}
+
+// PanicError is the error a pool task fails with when it panics, unless
+// WithWorkerRestart has a restart left for it. Value is what was recovered.
+type PanicError struct {
+	Index int // The task's position in its pool
+	Value any
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("gogo: task %d panicked: %v", e.Index, e.Value)
+}
+
+// call runs task i's fn, recovering a panic and passing it to the panic
+// handler.
+func (g *Pool[T]) call(i int, ctx context.Context, fn func(ctx context.Context) (T, error)) (res T, err error, recovered any) {
+	defer func() {
+		recovered = recover()
+		if recovered != nil && g.onPanic != nil {
+			g.onPanic(i, recovered)
+		}
+	}()
+	res, err = fn(ctx)
+	return res, err, nil
+}
+
+// WithWorkerRestart retries tasks that panic rather than failing them. A
+// worker whose task panics is replaced by a new worker goroutine which runs the
+// task again, up to max times across the whole pool. Once the restarts are used
+// up a panicking task fails like it would without them.
+func (g *Pool[T]) WithWorkerRestart(max int) *Pool[T] {
+	g.mustNotBeStarted()
+	g.restarts = &atomic.Int64{}
+	g.restarts.Store(int64(max))
+	return g
+}
+
+// WithPanicHandler calls f whenever a task panics, with the task's index and
+// the recovered value, from the deferred recover in the task's worker. f may
+// panic again to crash the program after all. If it returns, the panic is
+// handled as usual: the task fails with an error saying so, or is retried if
+// WithWorkerRestart has restarts left, in which case f runs again for each
+// panic.
+func (g *Pool[T]) WithPanicHandler(f func(i int, recovered any)) *Pool[T] {
+	g.mustNotBeStarted()
+	g.onPanic = f
+	return g
+}
+
+// emit delivers the result of task i.
+func (g *Pool[T]) emit(i int, res Optional[T]) {
+	if g.reorder != nil {
+		g.reorder.push(i, res, g.send)
+		return
+	}
+	g.send(res)
+}
+
+// progress counts a finished task and reports it to the progress callback.
+func (g *Pool[T]) progress() {
+	if g.onProgress == nil {
+		return
+	}
+	g.progressMu.Lock()
+	defer g.progressMu.Unlock()
+	g.completed++
+	g.onProgress(g.completed, g.size)
+}
+
+// OnProgress calls f each time a task finishes with the number finished so far
+// and the pool size. Calls are serialized, so the counts only go up, and they
+// all happen before the feed closes. f runs on the pool's workers and should
+// be cheap.
+func (g *Pool[T]) OnProgress(f func(completed, total int)) *Pool[T] {
+	g.mustNotBeStarted()
+	g.onProgress = f
+	return g
+}
+
+// skip records that task i has no result to deliver.
+func (g *Pool[T]) skip(i int) {
+	if g.reorder != nil {
+		g.reorder.skip(i, g.send)
+	}
+}
+
+func (g *Pool[T]) send(res Optional[T]) {
+	if g.keepAlive != nil {
+		g.keepAlive.touch()
+	}
+	if g.ptrFeed != nil {
+		ptr := new(Optional[T])
+		*ptr = res
+		g.ptrFeed <- ptr
+		return
+	}
+	if g.memory != nil {
+		g.memory.stage(g.ctx, res)
+		return
+	}
+	g.feed <- res
+}
+
+// GoPtr is an alternative to Go whose feed carries pointers to results rather
+// than copies of them, which saves copying large result types in and out of
+// the feed's buffer. A pool is either run with Go or with GoPtr; after GoPtr
+// the feed returned by Go stays empty. Calling GoPtr once the pool was started
+// with Go panics.
+func (g *Pool[T]) GoPtr() chan *Optional[T] {
+	g.ptrOnce.Do(func() {
+		g.mustNotBeStarted()
+		g.ptrFeed = make(chan *Optional[T], cap(g.feed))
+		g.feed = make(chan Optional[T]) // Nothing is sent here, don't hold a buffer
+	})
+	g.Go()
+	return g.ptrFeed
+}
+
+// taskContext returns the context task i runs with. With a time budget each
+// task gets an equal share of whatever is left of it.
+func (g *Pool[T]) taskContext(i int) (context.Context, context.CancelFunc) {
+	if g.budget <= 0 {
+		return g.ctx, func() {}
+	}
+	// Tasks left to run, in waves of concurrency
+	waves := (g.size - i + g.concurrency - 1) / g.concurrency
+	return context.WithTimeout(g.ctx, time.Until(g.deadline)/time.Duration(waves))
+}
+
+// WithTimeBudget spreads a total time budget across the pool's tasks. Each task
+// is given a timeout of whatever remains of the budget divided by the number
+// of tasks still to run (counted in waves of concurrency), so a slow start
+// shortens later timeouts instead of starving the last tasks entirely, and a
+// fast start leaves them more room. Only tasks that accept a context, see
+// NewPoolContext, can observe their timeout.
+func (g *Pool[T]) WithTimeBudget(total time.Duration) *Pool[T] {
+	g.mustNotBeStarted()
+	g.budget = total
+	return g
+}
+
+// WithSynchronousExecution runs the tasks one at a time, in index order, on
+// the goroutine that runs the pool instead of on a set of worker goroutines.
+// Results still flow through the feed. This makes pools deterministic, which
+// is mostly useful in tests of code that builds them.
+func (g *Pool[T]) WithSynchronousExecution() *Pool[T] {
+	g.mustNotBeStarted()
+	g.synchronous = true
+	return g
+}
+
+// WithUnbufferedFeed replaces the pool's feed, which by default can hold every
+// result, with an unbuffered one. Workers then block until the consumer takes
+// their result, so no more than concurrency results are ever held in memory.
+func (g *Pool[T]) WithUnbufferedFeed() *Pool[T] {
+	g.mustNotBeStarted()
+	g.feed = make(chan Optional[T])
+	return g
+}
+
+// WithMaxInFlight caps the feed's buffer at n results, instead of one per
+// task, so a slow consumer holds at most n finished results. Unlike
+// concurrency, it doesn't limit how many tasks run: a worker whose result
+// doesn't fit waits for the consumer to catch up before taking its next task.
+func (g *Pool[T]) WithMaxInFlight(n int) *Pool[T] {
+	g.mustNotBeStarted()
+	g.feed = make(chan Optional[T], n)
+	return g
+}
+
+// GoBuffered is an alternative to Go that starts the pool with a feed of
+// bufSize results, as WithMaxInFlight would. Like GoPtr, it panics once the
+// pool was started.
+func (g *Pool[T]) GoBuffered(bufSize int) <-chan Optional[T] {
+	return g.WithMaxInFlight(bufSize).Go()
+}
+
+// mustNotBeStarted guards the pool's builder methods. Once Go has been called
+// the workers have already read the configuration, so changing it afterwards
+// would silently have no effect.
+func (g *Pool[T]) mustNotBeStarted() {
+	if g.started.Load() {
+		panic("gogo: cannot configure pool after Go")
+	}
+}
+
+// WithValue makes val available under key in the context of every task.
+func (g *Pool[T]) WithValue(key, val any) *Pool[T] {
+	g.mustNotBeStarted()
+	g.ctx = context.WithValue(g.ctx, key, val)
+	return g
+}
+
+// WithTimeout cancels the pool once d has passed since WithTimeout was called.
+// Like Cancel, no further tasks are launched and running tasks see their
+// context cancelled, with a cause that says the pool timed out and wraps
+// context.DeadlineExceeded.
+func (g *Pool[T]) WithTimeout(d time.Duration) *Pool[T] {
+	return g.WithTimeoutCause(d, fmt.Errorf("gogo: pool timed out after %v: %w", d, context.DeadlineExceeded))
+}
+
+// WithTimeoutCause is like WithTimeout, but once the timeout fires tasks can
+// retrieve cause with context.Cause. ctx.Err() is still
+// context.DeadlineExceeded.
+func (g *Pool[T]) WithTimeoutCause(d time.Duration, cause error) *Pool[T] {
+	g.mustNotBeStarted()
+	ctx, cancel := context.WithTimeoutCause(g.ctx, d, cause)
+	parentCancel := g.cancel
+	g.ctx = ctx
+	g.cancel = func(cause error) {
+		parentCancel(cause) // First, so ctx is cancelled with cause too
+		cancel()
+	}
+	return g
+}
+
+// Cancel stops the pool from launching any more tasks and cancels the context
+// of the tasks already running, with ErrPoolCancelled as its cause. With
+// WithGracefulShutdown, their context is only cancelled once its timeout has
+// passed. Their results are still sent on the feed, which closes once they are
+// done.
+func (g *Pool[T]) Cancel() {
+	g.cancelWith(CancelUser)
+}
+
+// cancelWith is Cancel, recording reason unless the pool was already
+// cancelled.
+func (g *Pool[T]) cancelWith(reason CancelReason) {
+	g.cancelWithCause(reason, reason.cause())
+}
+
+// cancelWithCause is cancelWith, cancelling the pool's context with cause.
+func (g *Pool[T]) cancelWithCause(reason CancelReason, cause error) {
+	if g.ctx.Err() == nil {
+		g.cancelReason.CompareAndSwap(int32(CancelNone), int32(reason))
+	}
+	cancel := func() {
+		g.cancel(cause)
+	}
+	if g.shutdown != nil {
+		g.shutdown.begin(func() int { return g.Stats().Running }, cancel)
+		return
+	}
+	cancel()
+}
+
+// Wait runs the pool if it isn't running yet and waits for it to finish. The
+// results stay in the feed for Go to read afterwards, as long as it can hold
+// them all. If it can't, and its feed was never handed out by Go, Wait discards
+// the results instead of blocking forever.
 func (g *Pool[T]) Wait() {
-	g.Go() // Safe to call again in case they haven't!
+	g.start() // Safe to call again in case they haven't!
+	if !g.consumed.Load() && (cap(g.feed) < g.size || g.keepAlive != nil) {
+		for range g.feed {
+		}
+	}
 	g.wg.Wait()
 }
 
+// Drain runs the pool to completion for its side effects, reading and
+// discarding the results, and returns the errors of the tasks that failed
+// joined together. Unlike Wait, it doesn't depend on the feed having room for
+// every result.
+func (g *Pool[T]) Drain() error {
+	var errs []error
+	for res := range g.Go() {
+		if res.Error != nil {
+			errs = append(errs, res.Error)
+		}
+	}
+	return errors.Join(errs...)
+}
+
 func NewPool[T any](concurrency int, size int, fn func(i int) func() (T, error)) *Pool[T] {
+	return NewPoolContext(context.Background(), concurrency, size, func(i int) func(ctx context.Context) (T, error) {
+		task := fn(i)
+		return func(ctx context.Context) (T, error) {
+			return task()
+		}
+	})
+}
+
+// NewPoolContext is like NewPool, but every task is handed a context derived
+// from ctx. It is cancelled when ctx is, when the pool is cancelled, or when
+// the task runs out of its share of a time budget.
+func NewPoolContext[T any](ctx context.Context, concurrency int, size int, fn func(i int) func(ctx context.Context) (T, error)) *Pool[T] {
 	if concurrency > size {
 		concurrency = size
 	}
 	wg := &sync.WaitGroup{}
 	wg.Add(1)
+	parent := ctx
+	ctx, cancel := context.WithCancelCause(ctx)
 	return &Pool[T]{
+		parent:      parent,
+		ctx:         ctx,
+		cancel:      cancel,
 		concurrency: concurrency,
 		size:        size,
 		makeFn:      fn,
@@ -136,3 +778,40 @@ func NewPool[T any](concurrency int, size int, fn func(i int) func() (T, error))
 		wg:          wg,
 	}
 }
+
+// PoolOptions configures a pool built with NewPoolWith.
+type PoolOptions struct {
+	Concurrency int // How many tasks run at once
+	Size        int // How many tasks there are
+	// FeedBuffer is how many results the feed holds before workers wait for
+	// the consumer, as with WithMaxInFlight. Zero means Concurrency, and a
+	// negative value an unbuffered feed. Size gives the one result per task
+	// that NewPool buffers.
+	FeedBuffer int
+}
+
+// NewPoolWith is like NewPoolContext, configured by opts. Unlike the other
+// constructors, by default its feed only holds Concurrency results, so the
+// memory it takes grows with parallelism rather than the number of tasks.
+func NewPoolWith[T any](ctx context.Context, opts PoolOptions, fn func(i int) func(ctx context.Context) (T, error)) *Pool[T] {
+	pool := NewPoolContext(ctx, opts.Concurrency, opts.Size, fn)
+	buffer := opts.FeedBuffer
+	switch {
+	case buffer == 0:
+		buffer = pool.concurrency
+	case buffer < 0:
+		buffer = 0
+	}
+	return pool.WithMaxInFlight(buffer)
+}
+
+// PoolFromSlice returns a pool with one task per item, each running fn on its
+// item. It saves building an index based factory by hand.
+func PoolFromSlice[In any, T any](ctx context.Context, concurrency int, items []In, fn func(ctx context.Context, item In) (T, error)) *Pool[T] {
+	return NewPoolContext(ctx, concurrency, len(items), func(i int) func(ctx context.Context) (T, error) {
+		item := items[i]
+		return func(ctx context.Context) (T, error) {
+			return fn(ctx, item)
+		}
+	})
+}
@@ -1,9 +1,13 @@
 package gogo
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -16,8 +20,55 @@ func TestSpec(t *testing.T) {
 			http.Get("https://httpbin.org/uuid")
 		})
 		proc.Go()
-		So(proc.result.Error, ShouldEqual, nil)
-		So(proc.result.Result, ShouldResemble, http.Response{})
+		So(proc.result.Load().Error, ShouldEqual, nil)
+		So(proc.result.Load().Result, ShouldResemble, http.Response{})
+	})
+
+	Convey("Given a function with no result, Run should run it with the context", t, func() {
+		type key string
+		ctx := context.WithValue(context.Background(), key("name"), "run")
+		var saw atomic.Value
+		proc := Run(ctx, func(ctx context.Context) {
+			saw.Store(ctx.Value(key("name")))
+		})
+		proc.Wait()
+		So(saw.Load(), ShouldEqual, "run")
+		res, err := proc.Result()
+		So(err, ShouldBeNil)
+		So(res, ShouldResemble, struct{}{})
+		So(proc.Await(), ShouldBeNil)
+	})
+
+	Convey("Given a failing Proc, Await should return only its error", t, func() {
+		errFailed := errors.New("failed")
+		proc := Go(func() (int, error) {
+			return 1, errFailed
+		})
+		So(proc.Await(), ShouldEqual, errFailed)
+	})
+
+	Convey("Given pool options, NewPoolWith should size the feed to concurrency by default", t, func() {
+		fn := func(i int) func(ctx context.Context) (int, error) {
+			return func(ctx context.Context) (int, error) {
+				return i, nil
+			}
+		}
+		pool := NewPoolWith(context.Background(), PoolOptions{Concurrency: 4, Size: 100}, fn)
+		So(cap(pool.feed), ShouldEqual, 4)
+		sum := 0
+		for res := range pool.Go() {
+			sum += res.Result
+		}
+		So(sum, ShouldEqual, 4950)
+
+		pool = NewPoolWith(context.Background(), PoolOptions{Concurrency: 4, Size: 100, FeedBuffer: 100}, fn)
+		So(cap(pool.feed), ShouldEqual, 100)
+		pool.Wait()
+		So(len(pool.feed), ShouldEqual, 100)
+
+		pool = NewPoolWith(context.Background(), PoolOptions{Concurrency: 4, Size: 100, FeedBuffer: -1}, fn)
+		So(cap(pool.feed), ShouldEqual, 0)
+		pool.Wait() // Doesn't block on the unread feed
 	})
 
 	Convey("Given some function makes a list of strings and returns a list of ints", t, func() {
@@ -144,4 +195,385 @@ func TestSpec(t *testing.T) {
 		So(results, ShouldHaveLength, 4)
 		So(errors, ShouldHaveLength, 1)
 	})
+
+	Convey("Given a pool with an unbuffered feed, workers should wait for the consumer", t, func() {
+		var finished int32
+		group := NewPool(4, 20, func(i int) func() (int, error) {
+			return func() (int, error) {
+				atomic.AddInt32(&finished, 1)
+				return i, nil
+			}
+		}).WithUnbufferedFeed()
+		feed := group.Go()
+		time.Sleep(100 * time.Millisecond)
+		So(atomic.LoadInt32(&finished), ShouldEqual, 4)
+		count := 0
+		for range feed {
+			count++
+		}
+		So(count, ShouldEqual, 20)
+	})
+
+	Convey("Given a pool with a max in flight, a slow consumer should hold back the workers", t, func() {
+		var finished int32
+		group := NewPool(4, 20, func(i int) func() (int, error) {
+			return func() (int, error) {
+				atomic.AddInt32(&finished, 1)
+				return i, nil
+			}
+		}).WithMaxInFlight(6)
+		feed := group.Go()
+		time.Sleep(100 * time.Millisecond)
+		So(len(feed), ShouldEqual, 6)
+		So(atomic.LoadInt32(&finished), ShouldEqual, 10) // 6 buffered, 4 waiting to deliver
+		count := 0
+		for range feed {
+			So(len(feed), ShouldBeLessThanOrEqualTo, 6)
+			count++
+			time.Sleep(time.Millisecond)
+		}
+		So(count, ShouldEqual, 20)
+	})
+
+	Convey("Given a pool started with a tiny buffer, a slow consumer should hold the workers back", t, func() {
+		var finished int32
+		group := NewPool(2, 30, func(i int) func() (int, error) {
+			return func() (int, error) {
+				atomic.AddInt32(&finished, 1)
+				return i, nil
+			}
+		})
+		feed := group.GoBuffered(1)
+		So(cap(feed), ShouldEqual, 1)
+		time.Sleep(50 * time.Millisecond)
+		So(atomic.LoadInt32(&finished), ShouldEqual, 3) // 1 buffered, 2 waiting to deliver
+		var results []int
+		for res := range feed {
+			results = append(results, res.Result)
+			time.Sleep(time.Millisecond)
+		}
+		So(results, ShouldHaveLength, 30)
+		So(func() { group.GoBuffered(1) }, ShouldPanic)
+	})
+
+	Convey("Given a pool whose feed can't hold every result, Drain should run it to completion", t, func() {
+		var ran int32
+		group := NewPool(4, 100, func(i int) func() (int, error) {
+			return func() (int, error) {
+				atomic.AddInt32(&ran, 1)
+				if i%25 == 0 {
+					return 0, errors.New("write " + strconv.Itoa(i) + " failed")
+				}
+				return i, nil
+			}
+		}).WithMaxInFlight(2)
+		err := group.Drain()
+		So(atomic.LoadInt32(&ran), ShouldEqual, 100)
+		So(err, ShouldNotBeNil)
+		for _, i := range []string{"0", "25", "50", "75"} {
+			So(err.Error(), ShouldContainSubstring, "write "+i+" failed")
+		}
+		So(NewPool(2, 10, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		}).Drain(), ShouldBeNil)
+	})
+
+	Convey("Given a large pool nobody reads, Wait alone should finish", t, func() {
+		var ran int32
+		task := func(i int) func() (int, error) {
+			return func() (int, error) {
+				atomic.AddInt32(&ran, 1)
+				return i, nil
+			}
+		}
+		NewPool(8, 100000, task).Wait()
+		So(atomic.LoadInt32(&ran), ShouldEqual, 100000)
+		NewPool(8, 100000, task).WithMaxInFlight(16).Wait()
+		So(atomic.LoadInt32(&ran), ShouldEqual, 200000)
+		NewPool(8, 100000, task).WithUnbufferedFeed().Wait()
+		So(atomic.LoadInt32(&ran), ShouldEqual, 300000)
+	})
+
+	Convey("Given a pool that can buffer every result, Wait should leave them for the feed", t, func() {
+		group := NewPool(2, 10, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		})
+		group.Wait()
+		count := 0
+		for range group.Go() {
+			count++
+		}
+		So(count, ShouldEqual, 10)
+	})
+
+	Convey("Given a pool with worker restarts, panicking tasks should be rerun until the pool completes", t, func() {
+		attempts := make([]int32, 10)
+		group := NewPool(3, 10, func(i int) func() (int, error) {
+			return func() (int, error) {
+				if atomic.AddInt32(&attempts[i], 1) == 1 && i%3 == 0 {
+					panic("worker crashed")
+				}
+				return i, nil
+			}
+		}).WithWorkerRestart(5)
+		var results []int
+		for res := range group.Go() {
+			So(res.Error, ShouldBeNil)
+			results = append(results, res.Result)
+		}
+		So(results, ShouldHaveLength, 10)
+		So(atomic.LoadInt32(&attempts[0]), ShouldEqual, 2)
+		So(atomic.LoadInt32(&attempts[1]), ShouldEqual, 1)
+		So(group.restarts.Load(), ShouldEqual, 1)
+	})
+
+	Convey("Given a task that panics, the pool should fail that task and finish the rest", t, func() {
+		group := NewPool(2, 6, func(i int) func() (int, error) {
+			return func() (int, error) {
+				if i == 3 {
+					panic("boom")
+				}
+				return i, nil
+			}
+		})
+		results := map[int]Optional[int]{}
+		for res := range group.Go() {
+			results[res.Index] = res
+		}
+		So(results, ShouldHaveLength, 6)
+		So(results[3].Error, ShouldNotBeNil)
+		So(results[3].Error.Error(), ShouldEqual, "gogo: task 3 panicked: boom")
+		var panicErr *PanicError
+		So(errors.As(results[3].Error, &panicErr), ShouldBeTrue)
+		So(panicErr.Index, ShouldEqual, 3)
+		So(panicErr.Value, ShouldEqual, "boom")
+		So(results[5].Error, ShouldBeNil)
+		So(group.Stats().Failed, ShouldEqual, 1)
+	})
+
+	Convey("Given a pool whose restarts run out, further panics should fail their tasks", t, func() {
+		group := NewPool(1, 3, func(i int) func() (int, error) {
+			return func() (int, error) {
+				panic("always")
+			}
+		}).WithWorkerRestart(2)
+		failed := 0
+		for res := range group.Go() {
+			So(res.Error, ShouldNotBeNil)
+			failed++
+		}
+		So(failed, ShouldEqual, 3)
+	})
+
+	Convey("Given a panic handler, it should see every panic, restarted or not", t, func() {
+		var mu sync.Mutex
+		seen := map[int][]any{}
+		group := NewPool(2, 4, func(i int) func() (int, error) {
+			return func() (int, error) {
+				if i%2 == 1 {
+					panic(fmt.Sprintf("task %d", i))
+				}
+				return i, nil
+			}
+		}).WithWorkerRestart(1).WithPanicHandler(func(i int, recovered any) {
+			mu.Lock()
+			defer mu.Unlock()
+			seen[i] = append(seen[i], recovered)
+		})
+		failed := 0
+		for res := range group.Go() {
+			if res.Error != nil {
+				So(res.Result, ShouldBeZeroValue)
+				failed++
+			}
+		}
+		// One panic is retried and panics again, so the handler sees 3
+		So(len(seen[1])+len(seen[3]), ShouldEqual, 3)
+		So(seen[1][0], ShouldEqual, "task 1")
+		So(seen[3][0], ShouldEqual, "task 3")
+		So(failed, ShouldEqual, 2)
+	})
+
+	Convey("Given a pool of many tiny tasks, no more than concurrency should ever run at once", t, func() {
+		var running, peak int32
+		group := NewPool(8, 5000, func(i int) func() (int, error) {
+			return func() (int, error) {
+				now := atomic.AddInt32(&running, 1)
+				for {
+					prev := atomic.LoadInt32(&peak)
+					if now <= prev || atomic.CompareAndSwapInt32(&peak, prev, now) {
+						break
+					}
+				}
+				atomic.AddInt32(&running, -1)
+				return i, nil
+			}
+		})
+		sum := 0
+		for res := range group.Go() {
+			sum += res.Result
+		}
+		So(sum, ShouldEqual, 5000*4999/2)
+		So(atomic.LoadInt32(&peak), ShouldBeLessThanOrEqualTo, 8)
+	})
+
+	Convey("Given a running Proc, Done() should be safe to poll concurrently", t, func() {
+		proc := Go(func() (int, error) {
+			time.Sleep(50 * time.Millisecond)
+			return 42, nil
+		})
+		polls := 0
+		for !proc.Done() {
+			polls++
+		}
+		So(polls, ShouldBeGreaterThan, 0)
+		res, err := proc.Result()
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, 42)
+	})
+
+	Convey("Given a pool with a progress callback, the final call should report every task", t, func() {
+		var reports [][2]int
+		group := NewPool(4, 25, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		}).OnProgress(func(completed, total int) {
+			reports = append(reports, [2]int{completed, total})
+		})
+		feed := group.Go()
+		for range feed {
+		}
+		So(reports, ShouldHaveLength, 25)
+		for i, report := range reports {
+			So(report, ShouldEqual, [2]int{i + 1, 25})
+		}
+	})
+
+	Convey("Given a pool with a value, every task should see it in its context", t, func() {
+		type key struct{}
+		group := NewPoolContext(context.Background(), 2, 4, func(i int) func(ctx context.Context) (string, error) {
+			return func(ctx context.Context) (string, error) {
+				return ctx.Value(key{}).(string), nil
+			}
+		}).WithValue(key{}, "req-1")
+		for res := range group.Go() {
+			So(res.Result, ShouldEqual, "req-1")
+		}
+	})
+
+	Convey("Given a pool with a timeout, tasks should see their context expire", t, func() {
+		group := NewPoolContext(context.Background(), 1, 1, func(i int) func(ctx context.Context) (int, error) {
+			return func(ctx context.Context) (int, error) {
+				<-ctx.Done()
+				return 0, ctx.Err()
+			}
+		}).WithTimeout(50 * time.Millisecond)
+		res := <-group.Go()
+		So(res.Error, ShouldWrap, context.DeadlineExceeded)
+	})
+
+	Convey("Given a pool with a timeout cause, tasks should be able to retrieve the cause", t, func() {
+		errSlowUpstream := errors.New("upstream too slow")
+		group := NewPoolContext(context.Background(), 1, 1, func(i int) func(ctx context.Context) (int, error) {
+			return func(ctx context.Context) (int, error) {
+				<-ctx.Done()
+				return 0, context.Cause(ctx)
+			}
+		}).WithTimeoutCause(50*time.Millisecond, errSlowUpstream)
+		res := <-group.Go()
+		So(res.Error, ShouldEqual, errSlowUpstream)
+	})
+
+	Convey("Given a started pool, configuring it should panic instead of being ignored", t, func() {
+		group := NewPool(1, 1, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		})
+		group.Go()
+		So(func() { group.WithValue("reqID", "late") }, ShouldPanicWith, "gogo: cannot configure pool after Go")
+		So(func() { group.WithRateLimit(1) }, ShouldPanic)
+		group.Wait()
+	})
+
+	Convey("Given a synchronous pool, tasks should run one at a time in index order", t, func() {
+		var order []int
+		var running, peak int32
+		group := NewPool(4, 10, func(i int) func() (int, error) {
+			return func() (int, error) {
+				if now := atomic.AddInt32(&running, 1); now > peak {
+					peak = now
+				}
+				order = append(order, i)
+				atomic.AddInt32(&running, -1)
+				return i, nil
+			}
+		}).WithSynchronousExecution()
+		var results []int
+		for res := range group.Go() {
+			results = append(results, res.Result)
+		}
+		So(peak, ShouldEqual, 1)
+		So(order, ShouldResemble, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+		So(results, ShouldResemble, order)
+	})
+
+	Convey("Given a slice of inputs, PoolFromSlice should run one task per item", t, func() {
+		items := []string{"1", "2", "3", "four"}
+		group := PoolFromSlice(context.Background(), 2, items, func(ctx context.Context, item string) (int, error) {
+			return strconv.Atoi(item)
+		})
+		results := make(map[int]int)
+		var errs []error
+		for res := range group.Go() {
+			if res.Error != nil {
+				errs = append(errs, res.Error)
+				continue
+			}
+			results[res.Index] = res.Result
+		}
+		So(results, ShouldResemble, map[int]int{0: 1, 1: 2, 2: 3})
+		So(errs, ShouldHaveLength, 1)
+	})
+
+	Convey("Given a pool run with GoPtr, the feed should carry pointers to every result", t, func() {
+		group := NewPool(3, 10, func(i int) func() (int, error) {
+			return func() (int, error) {
+				if i == 4 {
+					return 0, errors.New("four")
+				}
+				return i, nil
+			}
+		})
+		feed := group.GoPtr()
+		So(group.GoPtr(), ShouldEqual, feed)
+		sum, errs := 0, 0
+		for res := range feed {
+			if res.Error != nil {
+				errs++
+				continue
+			}
+			sum += res.Result
+		}
+		So(sum, ShouldEqual, 41)
+		So(errs, ShouldEqual, 1)
+		_, ok := <-group.Go()
+		So(ok, ShouldBeFalse)
+	})
+
+	Convey("Given a pool started with Go, GoPtr should panic", t, func() {
+		group := NewPool(1, 1, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		})
+		group.Go()
+		So(func() { group.GoPtr() }, ShouldPanic)
+		group.Wait()
+	})
 }
@@ -0,0 +1,32 @@
+// Package gogoexec runs external commands as gogo Procs.
+package gogoexec
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/stcrestrada/gogo"
+)
+
+// Command runs name with args in the background and returns a Proc of what it
+// wrote to stdout. The command is killed if ctx is cancelled. If it exits with
+// a non-zero status the Proc fails with an error wrapping the *exec.ExitError
+// and carrying whatever the command wrote to stderr.
+func Command(ctx context.Context, name string, args ...string) *gogo.Proc[[]byte] {
+	return gogo.GoContext(ctx, func(ctx context.Context) ([]byte, error) {
+		var stdout, stderr bytes.Buffer
+		cmd := exec.CommandContext(ctx, name, args...)
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return stdout.Bytes(), fmt.Errorf("%s: %w: %s", name, err, msg)
+			}
+			return stdout.Bytes(), fmt.Errorf("%s: %w", name, err)
+		}
+		return stdout.Bytes(), nil
+	})
+}
@@ -0,0 +1,37 @@
+package gogoexec
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCommand(t *testing.T) {
+	Convey("Given a command that succeeds, the Proc should resolve to its output", t, func() {
+		out, err := Command(context.Background(), "echo", "hello", "gogo").Result()
+		So(err, ShouldBeNil)
+		So(string(out), ShouldEqual, "hello gogo\n")
+	})
+
+	Convey("Given a command that exits non-zero, the Proc should fail with its stderr", t, func() {
+		_, err := Command(context.Background(), "sh", "-c", "echo oops >&2; exit 3").Result()
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldContainSubstring, "oops")
+		var exitErr *exec.ExitError
+		So(errors.As(err, &exitErr), ShouldBeTrue)
+		So(exitErr.ExitCode(), ShouldEqual, 3)
+	})
+
+	Convey("Given a cancelled context, the command should be killed", t, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err := Command(ctx, "sleep", "5").Result()
+		So(err, ShouldNotBeNil)
+		So(time.Since(start), ShouldBeLessThan, time.Second)
+	})
+}
@@ -0,0 +1,50 @@
+package gogo
+
+import (
+	"context"
+	"sync"
+)
+
+// Group deduplicates concurrent work by key, like
+// golang.org/x/sync/singleflight, but hands out Procs.
+type Group[K comparable, T any] struct {
+	mu    sync.Mutex
+	calls map[K]*Proc[T]
+}
+
+// NewGroup returns an empty Group, ready for Do. A Group is safe for concurrent
+// use and, unlike a pool, isn't tied to a context of its own: each call to Do
+// brings one.
+func NewGroup[K comparable, T any]() *Group[K, T] {
+	return &Group[K, T]{
+		calls: make(map[K]*Proc[T]),
+	}
+}
+
+// Do runs fn under ctx and returns a Proc of its result. While that call is in
+// flight, further calls for the same key return the same Proc instead of
+// running fn again, and their ctx and fn are ignored. Once the call finishes
+// the key is forgotten, so the next Do runs fn afresh.
+func (g *Group[K, T]) Do(ctx context.Context, key K, fn func(ctx context.Context) (T, error)) *Proc[T] {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if proc, ok := g.calls[key]; ok {
+		return proc
+	}
+	var proc *Proc[T]
+	proc = Lazy(ctx, func(ctx context.Context) (T, error) {
+		defer g.forget(key, proc)
+		return fn(ctx)
+	})
+	g.calls[key] = proc
+	go proc.Go()
+	return proc
+}
+
+func (g *Group[K, T]) forget(key K, proc *Proc[T]) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.calls[key] == proc {
+		delete(g.calls, key)
+	}
+}
@@ -0,0 +1,62 @@
+package gogo
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGroup(t *testing.T) {
+	Convey("Given concurrent calls for the same key, Do should run fn once and share its result", t, func() {
+		group := NewGroup[string, int]()
+		var runs int32
+		release := make(chan struct{})
+		fn := func(ctx context.Context) (int, error) {
+			atomic.AddInt32(&runs, 1)
+			<-release
+			return 42, nil
+		}
+		procs := make([]*Proc[int], 10)
+		var callers sync.WaitGroup
+		callers.Add(len(procs))
+		for i := range procs {
+			go func() {
+				procs[i] = group.Do(context.Background(), "answer", fn)
+				callers.Done()
+			}()
+		}
+		callers.Wait()
+		close(release)
+		for _, proc := range procs {
+			So(proc, ShouldEqual, procs[0])
+			res, err := proc.Result()
+			So(err, ShouldBeNil)
+			So(res, ShouldEqual, 42)
+		}
+		So(atomic.LoadInt32(&runs), ShouldEqual, 1)
+
+		Convey("Once the call has finished, the next Do should run fn again", func() {
+			res, err := group.Do(context.Background(), "answer", fn).Result()
+			So(err, ShouldBeNil)
+			So(res, ShouldEqual, 42)
+			So(atomic.LoadInt32(&runs), ShouldEqual, 2)
+		})
+	})
+
+	Convey("Given different keys, Do should run fn for each", t, func() {
+		group := NewGroup[int, int]()
+		a := group.Do(context.Background(), 1, func(ctx context.Context) (int, error) {
+			return 1, nil
+		})
+		b := group.Do(context.Background(), 2, func(ctx context.Context) (int, error) {
+			return 2, nil
+		})
+		resA, _ := a.Result()
+		resB, _ := b.Result()
+		So(resA, ShouldEqual, 1)
+		So(resB, ShouldEqual, 2)
+	})
+}
@@ -0,0 +1,75 @@
+package gogo
+
+import (
+	"context"
+	"iter"
+)
+
+// Iterate drives the pool and yields each result as it arrives, so it can be
+// ranged over directly:
+//
+//	for res := range gogo.Iterate(pool) {
+//		...
+//	}
+//
+// Results are only pulled from the feed as fast as the loop body consumes
+// them. If the loop stops early the pool is cancelled so no further tasks are
+// launched, and the results of the ones already running are discarded so they
+// don't block on the feed.
+func Iterate[T any](pool *Pool[T]) iter.Seq[Optional[T]] {
+	return func(yield func(Optional[T]) bool) {
+		feed := pool.Go()
+		for res := range feed {
+			if !yield(res) {
+				pool.Cancel()
+				go func() {
+					for range feed {
+					}
+				}()
+				return
+			}
+		}
+	}
+}
+
+// Iter is the method form of Iterate.
+func (g *Pool[T]) Iter() iter.Seq[Optional[T]] {
+	return Iterate(g)
+}
+
+// Iter2 is like Iter but also yields the index of the task each result came
+// from.
+func (g *Pool[T]) Iter2() iter.Seq2[int, Optional[T]] {
+	return func(yield func(int, Optional[T]) bool) {
+		for res := range g.Iter() {
+			if !yield(res.Index, res) {
+				return
+			}
+		}
+	}
+}
+
+// ForEach runs the pool and calls f with each result as it arrives. It returns
+// once f has been called for every result.
+func (g *Pool[T]) ForEach(f func(Optional[T])) {
+	for res := range g.Go() {
+		f(res)
+	}
+}
+
+// ForEachCtx is like ForEach, but f is handed the pool's context and may fail.
+// The first error from f cancels the pool, and f isn't called again. The
+// results of tasks that were already running are discarded, and ForEachCtx
+// returns that error once they have finished.
+func (g *Pool[T]) ForEachCtx(f func(ctx context.Context, res Optional[T]) error) error {
+	var err error
+	for res := range g.Go() {
+		if err != nil {
+			continue
+		}
+		if err = f(g.ctx, res); err != nil {
+			g.Cancel()
+		}
+	}
+	return err
+}
@@ -0,0 +1,173 @@
+package gogo
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestIterate(t *testing.T) {
+	Convey("Given a pool ranged with Iterate, every result should be yielded", t, func() {
+		pool := NewPool(3, 10, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		})
+		sum := 0
+		for res := range Iterate(pool) {
+			So(res.Error, ShouldBeNil)
+			sum += res.Result
+		}
+		So(sum, ShouldEqual, 45)
+	})
+
+	Convey("Given a pool ranged with Iterate, breaking early should cancel the pool", t, func() {
+		var started int32
+		pool := NewPool(1, 10, func(i int) func() (int, error) {
+			return func() (int, error) {
+				atomic.AddInt32(&started, 1)
+				time.Sleep(10 * time.Millisecond)
+				return i, nil
+			}
+		})
+		var seen []int
+		for res := range Iterate(pool) {
+			seen = append(seen, res.Result)
+			if len(seen) == 2 {
+				break
+			}
+		}
+		pool.Wait()
+		So(seen, ShouldHaveLength, 2)
+		So(pool.ctx.Err(), ShouldEqual, context.Canceled)
+		So(atomic.LoadInt32(&started), ShouldBeLessThan, 10)
+	})
+
+	Convey("Given an unbuffered feed, breaking early should still let Wait return", t, func() {
+		pool := NewPoolWith(context.Background(), PoolOptions{Concurrency: 4, Size: 20, FeedBuffer: -1}, func(i int) func(ctx context.Context) (int, error) {
+			return func(ctx context.Context) (int, error) {
+				time.Sleep(5 * time.Millisecond)
+				return i, nil
+			}
+		})
+		for range Iterate(pool) {
+			break
+		}
+		done := make(chan struct{})
+		go func() {
+			pool.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			So("Wait never returned", ShouldBeEmpty)
+		}
+	})
+}
+
+func TestPoolIter(t *testing.T) {
+	Convey("Given a pool ranged with Iter, every result should be yielded", t, func() {
+		pool := NewPool(3, 10, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i * 2, nil
+			}
+		})
+		count := 0
+		for res := range pool.Iter() {
+			So(res.Result, ShouldEqual, res.Index*2)
+			count++
+		}
+		So(count, ShouldEqual, 10)
+	})
+
+	Convey("Given a pool ranged with Iter2, each result should come with its task index", t, func() {
+		pool := NewPool(3, 10, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i * 2, nil
+			}
+		})
+		seen := make(map[int]int)
+		for i, res := range pool.Iter2() {
+			seen[i] = res.Result
+		}
+		So(seen, ShouldHaveLength, 10)
+		for i, v := range seen {
+			So(v, ShouldEqual, i*2)
+		}
+	})
+
+	Convey("Given a pool ranged with Iter2, breaking early should cancel the pool", t, func() {
+		var started int32
+		pool := NewPool(1, 10, func(i int) func() (int, error) {
+			return func() (int, error) {
+				atomic.AddInt32(&started, 1)
+				time.Sleep(10 * time.Millisecond)
+				return i, nil
+			}
+		})
+		for range pool.Iter2() {
+			break
+		}
+		pool.Wait()
+		So(pool.ctx.Err(), ShouldEqual, context.Canceled)
+		So(atomic.LoadInt32(&started), ShouldBeLessThan, 10)
+	})
+}
+
+func TestForEach(t *testing.T) {
+	Convey("Given a pool run with ForEach, f should see every result before it returns", t, func() {
+		pool := NewPool(3, 10, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		})
+		sum := 0
+		pool.ForEach(func(res Optional[int]) {
+			sum += res.Result
+		})
+		So(sum, ShouldEqual, 45)
+	})
+
+	Convey("Given ForEachCtx whose callback fails, the pool should be cancelled and the error returned", t, func() {
+		var launched int32
+		pool := NewPool(2, 100, func(i int) func() (int, error) {
+			return func() (int, error) {
+				atomic.AddInt32(&launched, 1)
+				time.Sleep(time.Millisecond)
+				return i, nil
+			}
+		})
+		errStop := errors.New("stop")
+		calls := 0
+		err := pool.ForEachCtx(func(ctx context.Context, res Optional[int]) error {
+			calls++
+			if calls == 3 {
+				return errStop
+			}
+			So(ctx.Err(), ShouldBeNil)
+			return nil
+		})
+		So(err, ShouldEqual, errStop)
+		So(calls, ShouldEqual, 3)
+		So(atomic.LoadInt32(&launched), ShouldBeLessThan, 100)
+	})
+
+	Convey("Given ForEachCtx whose callback never fails, it should return nil", t, func() {
+		pool := NewPool(2, 5, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		})
+		count := 0
+		So(pool.ForEachCtx(func(ctx context.Context, res Optional[int]) error {
+			count++
+			return nil
+		}), ShouldBeNil)
+		So(count, ShouldEqual, 5)
+	})
+}
@@ -0,0 +1,93 @@
+package gogo
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// keepAlive sends a placeholder on a pool's feed whenever no result has been
+// sent for a while.
+type keepAlive[T any] struct {
+	interval time.Duration
+	value    Optional[T]
+	last     atomic.Int64 // When something was last sent, in Unix nanoseconds
+}
+
+func (k *keepAlive[T]) touch() {
+	k.last.Store(time.Now().UnixNano())
+}
+
+// run sends keep-alives with send until the returned function is called,
+// which waits for it to stop.
+func (k *keepAlive[T]) run(send func(Optional[T]) bool) func() {
+	stop := make(chan struct{})
+	stopped := make(chan struct{})
+	k.touch()
+	go func() {
+		defer close(stopped)
+		timer := time.NewTimer(k.interval)
+		defer timer.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-timer.C:
+			}
+			idle := time.Since(time.Unix(0, k.last.Load()))
+			if idle < k.interval {
+				timer.Reset(k.interval - idle)
+				continue
+			}
+			send(k.value)
+			k.touch()
+			timer.Reset(k.interval)
+		}
+	}()
+	return func() {
+		close(stop)
+		<-stopped
+	}
+}
+
+// WithKeepAlive sends value on the feed whenever interval passes without a
+// result being sent, for instance to keep a streaming HTTP response alive
+// while tasks are slow. Keep-alives pause while results are flowing and pick
+// up again in any later gap, until the pool is done. They are only sent if
+// the feed has room for them right away.
+//
+// Keep-alives have an Index of -1 and don't count as results. Go, Iterate and
+// ForEach hand them out like any other, while the helpers that gather
+// results, like WaitAll, Results, CollectArray, GroupBy, Merge and Chain,
+// leave them out.
+func (g *Pool[T]) WithKeepAlive(interval time.Duration, value T) *Pool[T] {
+	g.mustNotBeStarted()
+	g.keepAlive = &keepAlive[T]{
+		interval: interval,
+		value:    Optional[T]{Result: value, Index: -1},
+	}
+	return g
+}
+
+// isKeepAlive reports whether res is a placeholder sent by WithKeepAlive
+// rather than a task's result.
+func (res Optional[T]) isKeepAlive() bool {
+	return res.Index < 0
+}
+
+// trySend sends res on the feed if it can do so without blocking.
+func (g *Pool[T]) trySend(res Optional[T]) bool {
+	if g.ptrFeed != nil {
+		select {
+		case g.ptrFeed <- &res:
+			return true
+		default:
+			return false
+		}
+	}
+	select {
+	case g.feed <- res:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,94 @@
+package gogo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestKeepAlive(t *testing.T) {
+	Convey("Given slow tasks, keep-alives should fill the gaps between results", t, func() {
+		pool := NewPool(1, 3, func(i int) func() (string, error) {
+			return func() (string, error) {
+				time.Sleep(50 * time.Millisecond)
+				return "result", nil
+			}
+		}).WithKeepAlive(10*time.Millisecond, "ping")
+		results, pings := 0, 0
+		for res := range pool.Go() {
+			if res.Index == -1 {
+				So(res.Result, ShouldEqual, "ping")
+				pings++
+				continue
+			}
+			So(res.Result, ShouldEqual, "result")
+			results++
+		}
+		So(results, ShouldEqual, 3)
+		So(pings, ShouldBeGreaterThanOrEqualTo, 6)
+	})
+
+	Convey("Given fast tasks, no keep-alive should be sent", t, func() {
+		pool := NewPool(2, 20, func(i int) func() (string, error) {
+			return func() (string, error) {
+				return "result", nil
+			}
+		}).WithKeepAlive(time.Second, "ping")
+		for res := range pool.Go() {
+			So(res.Index, ShouldBeGreaterThanOrEqualTo, 0)
+		}
+	})
+
+	Convey("Given a pool with keep-alives that nobody reads, Wait should still finish", t, func() {
+		pool := NewPool(1, 3, func(i int) func() (string, error) {
+			return func() (string, error) {
+				time.Sleep(20 * time.Millisecond)
+				return "result", nil
+			}
+		}).WithKeepAlive(5*time.Millisecond, "ping")
+		pool.Wait()
+		So(pool.closed, ShouldBeTrue)
+	})
+
+	Convey("Given keep-alives, the helpers that gather results should leave them out", t, func() {
+		slow := func() *Pool[int] {
+			return NewPool(1, 3, func(i int) func() (int, error) {
+				return func() (int, error) {
+					time.Sleep(30 * time.Millisecond)
+					return i + 1, nil
+				}
+			}).WithKeepAlive(5*time.Millisecond, -1)
+		}
+
+		array, err := CollectArray(slow())
+		So(err, ShouldBeNil)
+		So(array, ShouldHaveLength, 3)
+		So(array[2].Result, ShouldEqual, 3)
+
+		all, err := slow().WaitAll()
+		So(err, ShouldBeNil)
+		So(all, ShouldHaveLength, 3)
+
+		results, err := slow().Results()
+		So(err, ShouldBeNil)
+		So(results, ShouldResemble, []int{1, 2, 3})
+
+		groups := GroupBy(slow(), func(v int) bool { return v > 0 })
+		So(groups, ShouldResemble, map[bool][]int{true: {1, 2, 3}})
+
+		merged := 0
+		for res := range Merge(slow(), slow()) {
+			So(res.Index, ShouldBeGreaterThanOrEqualTo, 0)
+			merged++
+		}
+		So(merged, ShouldEqual, 6)
+
+		doubled, err := Chain(context.Background(), slow(), 1, func(ctx context.Context, v int) (int, error) {
+			return v * 2, nil
+		}).Results()
+		So(err, ShouldBeNil)
+		So(doubled, ShouldResemble, []int{2, 4, 6})
+	})
+}
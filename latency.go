@@ -0,0 +1,73 @@
+package gogo
+
+import (
+	"math/rand/v2"
+	"slices"
+	"sync"
+	"time"
+)
+
+// maxLatencySamples bounds how many task durations a pool keeps. Past that a
+// uniform random sample of them is kept instead.
+const maxLatencySamples = 4096
+
+// latencySampler keeps a bounded reservoir sample of task durations.
+type latencySampler struct {
+	mu      sync.Mutex
+	seen    int64
+	samples []time.Duration
+}
+
+func (s *latencySampler) record(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seen++
+	if len(s.samples) < maxLatencySamples {
+		s.samples = append(s.samples, d)
+		return
+	}
+	if j := rand.Int64N(s.seen); j < maxLatencySamples {
+		s.samples[j] = d
+	}
+}
+
+// percentiles returns the duration at each of the given percentiles, using
+// the nearest rank.
+func (s *latencySampler) percentiles(ps ...float64) map[float64]time.Duration {
+	s.mu.Lock()
+	sorted := slices.Clone(s.samples)
+	s.mu.Unlock()
+	slices.Sort(sorted)
+
+	out := make(map[float64]time.Duration, len(ps))
+	if len(sorted) == 0 {
+		return out
+	}
+	for _, p := range ps {
+		rank := int(p*float64(len(sorted))+0.5) - 1
+		rank = max(0, min(rank, len(sorted)-1))
+		out[p] = sorted[rank]
+	}
+	return out
+}
+
+// WithLatencyTracking times every task so LatencyPercentiles can report on
+// them. It is off by default since it adds measurable overhead to pools of
+// very small tasks.
+func (g *Pool[T]) WithLatencyTracking() *Pool[T] {
+	g.mustNotBeStarted()
+	g.latencies = &latencySampler{}
+	return g
+}
+
+// LatencyPercentiles returns the p50, p95 and p99 task durations, keyed by 0.5,
+// 0.95 and 0.99. It is meant to be read once the pool has finished. Pools with
+// more than a few thousand tasks report percentiles over a random sample of
+// them. The map is empty if no task has finished yet or the pool was not
+// created with WithLatencyTracking.
+func (g *Pool[T]) LatencyPercentiles() map[float64]time.Duration {
+	if g.latencies == nil {
+		return map[float64]time.Duration{}
+	}
+	return g.latencies.percentiles(0.5, 0.95, 0.99)
+}
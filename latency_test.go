@@ -0,0 +1,64 @@
+package gogo
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLatencyPercentiles(t *testing.T) {
+	Convey("Given tasks with known durations, LatencyPercentiles should report matching percentiles", t, func() {
+		// 90 fast tasks, 8 medium ones and 2 slow ones
+		pool := NewPool(100, 100, func(i int) func() (int, error) {
+			return func() (int, error) {
+				switch {
+				case i < 90:
+					time.Sleep(10 * time.Millisecond)
+				case i < 98:
+					time.Sleep(100 * time.Millisecond)
+				default:
+					time.Sleep(300 * time.Millisecond)
+				}
+				return i, nil
+			}
+		}).WithLatencyTracking()
+		pool.Wait()
+		p := pool.LatencyPercentiles()
+		So(p, ShouldHaveLength, 3)
+		So(p[0.5], ShouldBeBetween, 10*time.Millisecond, 50*time.Millisecond)
+		So(p[0.95], ShouldBeBetween, 100*time.Millisecond, 200*time.Millisecond)
+		So(p[0.99], ShouldBeGreaterThanOrEqualTo, 300*time.Millisecond)
+	})
+
+	Convey("Given more tasks than the sample bound, memory should stay bounded", t, func() {
+		pool := NewPool(8, maxLatencySamples*2, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		}).WithLatencyTracking()
+		pool.Wait()
+		So(pool.latencies.samples, ShouldHaveLength, maxLatencySamples)
+		So(pool.latencies.seen, ShouldEqual, maxLatencySamples*2)
+		So(pool.LatencyPercentiles(), ShouldHaveLength, 3)
+	})
+
+	Convey("Given a pool that has not run, LatencyPercentiles should be empty", t, func() {
+		pool := NewPool(1, 1, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		}).WithLatencyTracking()
+		So(pool.LatencyPercentiles(), ShouldBeEmpty)
+	})
+
+	Convey("Given a pool without latency tracking, LatencyPercentiles should be empty", t, func() {
+		pool := NewPool(1, 1, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		})
+		pool.Wait()
+		So(pool.LatencyPercentiles(), ShouldBeEmpty)
+	})
+}
@@ -0,0 +1,34 @@
+package gogo
+
+import (
+	"context"
+)
+
+// ConcurrencyLimiter caps how many tasks run at once across every pool that
+// shares it, on top of each pool's own concurrency.
+type ConcurrencyLimiter struct {
+	sem *semaphore
+}
+
+// NewConcurrencyLimiter returns a ConcurrencyLimiter allowing n tasks to run at
+// once.
+func NewConcurrencyLimiter(n int) *ConcurrencyLimiter {
+	return &ConcurrencyLimiter{
+		sem: newSemaphore(n),
+	}
+}
+
+type limiterKey struct{}
+
+// WithLimiterInContext returns a copy of ctx carrying limiter. Pools created
+// with that context, or one derived from it, honor the limiter without any
+// further wiring, so a single limiter can cap every pool spawned while serving
+// a request.
+func WithLimiterInContext(ctx context.Context, limiter *ConcurrencyLimiter) context.Context {
+	return context.WithValue(ctx, limiterKey{}, limiter)
+}
+
+func limiterFromContext(ctx context.Context) *ConcurrencyLimiter {
+	limiter, _ := ctx.Value(limiterKey{}).(*ConcurrencyLimiter)
+	return limiter
+}
@@ -0,0 +1,64 @@
+package gogo
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestLimiterInContext(t *testing.T) {
+	Convey("Given two pools under one context-bound limiter, their combined concurrency should be capped", t, func() {
+		var running, peak int32
+		task := func(ctx context.Context) (int, error) {
+			now := atomic.AddInt32(&running, 1)
+			for {
+				prev := atomic.LoadInt32(&peak)
+				if now <= prev || atomic.CompareAndSwapInt32(&peak, prev, now) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			return 0, nil
+		}
+		ctx := WithLimiterInContext(context.Background(), NewConcurrencyLimiter(3))
+		first := NewPoolContext(ctx, 5, 20, func(i int) func(ctx context.Context) (int, error) {
+			return task
+		})
+		second := NewPoolContext(ctx, 5, 20, func(i int) func(ctx context.Context) (int, error) {
+			return task
+		})
+		first.Go()
+		second.Go()
+		first.Wait()
+		second.Wait()
+		So(atomic.LoadInt32(&peak), ShouldEqual, 3)
+	})
+
+	Convey("Given a pool waiting on a shared limiter, cancelling it should not hang", t, func() {
+		limiter := NewConcurrencyLimiter(1)
+		ctx := WithLimiterInContext(context.Background(), limiter)
+		blocker := NewPoolContext(ctx, 1, 1, func(i int) func(ctx context.Context) (int, error) {
+			return func(ctx context.Context) (int, error) {
+				time.Sleep(300 * time.Millisecond)
+				return 0, nil
+			}
+		})
+		blocker.Go()
+		time.Sleep(20 * time.Millisecond)
+		waiting := NewPoolContext(ctx, 1, 5, func(i int) func(ctx context.Context) (int, error) {
+			return func(ctx context.Context) (int, error) {
+				return i, nil
+			}
+		})
+		waiting.Go()
+		start := time.Now()
+		waiting.Cancel()
+		waiting.Wait()
+		So(time.Since(start), ShouldBeLessThan, 200*time.Millisecond)
+		blocker.Wait()
+	})
+}
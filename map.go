@@ -0,0 +1,31 @@
+package gogo
+
+import (
+	"context"
+)
+
+// Map runs fn over items, at most concurrency at a time, and returns the
+// outputs in the order of items. The first error cancels the rest, and Map
+// returns it, once the calls already running have finished, with no outputs.
+// A concurrency below 1 runs one call at a time.
+func Map[In any, Out any](ctx context.Context, concurrency int, items []In, fn func(context.Context, In) (Out, error)) ([]Out, error) {
+	out := make([]Out, len(items))
+	err := PoolFromSlice(ctx, max(concurrency, 1), items, fn).ForEachCtx(func(_ context.Context, res Optional[Out]) error {
+		if res.Error != nil {
+			return res.Error
+		}
+		out[res.Index] = res.Result
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// MapAll is like Map, but runs fn over every item whatever the errors, and
+// returns every outcome, in the order of items, along with the errors joined
+// together.
+func MapAll[In any, Out any](ctx context.Context, concurrency int, items []In, fn func(context.Context, In) (Out, error)) ([]Optional[Out], error) {
+	return CollectArray(PoolFromSlice(ctx, max(concurrency, 1), items, fn))
+}
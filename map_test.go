@@ -0,0 +1,88 @@
+package gogo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMap(t *testing.T) {
+	Convey("Given a slice, Map should return the outputs in input order", t, func() {
+		items := []int{5, 1, 4, 2, 3}
+		var running, peak atomic.Int32
+		out, err := Map(context.Background(), 2, items, func(ctx context.Context, v int) (string, error) {
+			now := running.Add(1)
+			for {
+				prev := peak.Load()
+				if now <= prev || peak.CompareAndSwap(prev, now) {
+					break
+				}
+			}
+			time.Sleep(time.Duration(v) * time.Millisecond)
+			running.Add(-1)
+			return fmt.Sprint(v * 10), nil
+		})
+		So(err, ShouldBeNil)
+		So(out, ShouldResemble, []string{"50", "10", "40", "20", "30"})
+		So(peak.Load(), ShouldBeLessThanOrEqualTo, 2)
+	})
+
+	Convey("Given a failing item, Map should return its error and cancel the rest", t, func() {
+		errBad := errors.New("bad item")
+		var started atomic.Int32
+		items := make([]int, 100)
+		for i := range items {
+			items[i] = i
+		}
+		out, err := Map(context.Background(), 2, items, func(ctx context.Context, v int) (int, error) {
+			started.Add(1)
+			if v == 1 {
+				return 0, errBad
+			}
+			select {
+			case <-time.After(20 * time.Millisecond):
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			}
+			return v, nil
+		})
+		So(err, ShouldEqual, errBad)
+		So(out, ShouldBeNil)
+		So(started.Load(), ShouldBeLessThan, 100)
+	})
+
+	Convey("Given failing items, MapAll should keep every outcome", t, func() {
+		out, err := MapAll(context.Background(), 3, []int{1, 2, 3, 4}, func(ctx context.Context, v int) (int, error) {
+			if v%2 == 0 {
+				return 0, fmt.Errorf("%d is even", v)
+			}
+			return v * v, nil
+		})
+		So(out, ShouldHaveLength, 4)
+		So(out[0].Result, ShouldEqual, 1)
+		So(out[1].Error, ShouldNotBeNil)
+		So(out[2].Result, ShouldEqual, 9)
+		So(out[3].Error.Error(), ShouldEqual, "4 is even")
+		So(err.Error(), ShouldContainSubstring, "2 is even")
+	})
+
+	Convey("Given a concurrency below 1, Map and MapAll should still run every item", t, func() {
+		double := func(ctx context.Context, v int) (int, error) {
+			return v * 2, nil
+		}
+		for _, concurrency := range []int{0, -1} {
+			out, err := Map(context.Background(), concurrency, []int{1, 2, 3}, double)
+			So(err, ShouldBeNil)
+			So(out, ShouldResemble, []int{2, 4, 6})
+
+			all, err := MapAll(context.Background(), concurrency, []int{1, 2, 3}, double)
+			So(err, ShouldBeNil)
+			So(all[2].Result, ShouldEqual, 6)
+		}
+	})
+}
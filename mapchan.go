@@ -0,0 +1,48 @@
+package gogo
+
+import (
+	"context"
+	"sync"
+)
+
+// MapChan runs fn on every value received from in, with at most concurrency
+// running at once, and returns a channel of the outputs. It is the streaming
+// counterpart of a pool for input of unknown length. The returned channel
+// closes once in is closed and drained and every output has been delivered,
+// or once ctx is done, in which case remaining input is left unread.
+func MapChan[In any, Out any](ctx context.Context, concurrency int, in <-chan In, fn func(context.Context, In) (Out, error)) <-chan Optional[Out] {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	out := make(chan Optional[Out], concurrency)
+	wg := &sync.WaitGroup{}
+	wg.Add(concurrency)
+	for w := 0; w < concurrency; w++ {
+		go func() {
+			defer wg.Done()
+			for {
+				var value In
+				var ok bool
+				select {
+				case value, ok = <-in:
+					if !ok {
+						return
+					}
+				case <-ctx.Done():
+					return
+				}
+				res, err := fn(ctx, value)
+				select {
+				case out <- Optional[Out]{Result: res, Error: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
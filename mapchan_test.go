@@ -0,0 +1,65 @@
+package gogo
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMapChan(t *testing.T) {
+	Convey("Given a producer channel, MapChan should process every item with bounded concurrency", t, func() {
+		in := make(chan int)
+		go func() {
+			for i := 0; i < 50; i++ {
+				in <- i
+			}
+			close(in)
+		}()
+		var running, peak int32
+		out := MapChan(context.Background(), 4, in, func(ctx context.Context, v int) (int, error) {
+			now := atomic.AddInt32(&running, 1)
+			for {
+				prev := atomic.LoadInt32(&peak)
+				if now <= prev || atomic.CompareAndSwapInt32(&peak, prev, now) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			if v == 13 {
+				return 0, errors.New("unlucky")
+			}
+			return v * 2, nil
+		})
+		sum, errs := 0, 0
+		for res := range out {
+			if res.Error != nil {
+				errs++
+				continue
+			}
+			sum += res.Result
+		}
+		So(sum, ShouldEqual, 2*(49*50/2-13))
+		So(errs, ShouldEqual, 1)
+		So(atomic.LoadInt32(&peak), ShouldBeLessThanOrEqualTo, 4)
+	})
+
+	Convey("Given a cancelled context, MapChan should close its output without draining the input", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		in := make(chan int)
+		out := MapChan(ctx, 2, in, func(ctx context.Context, v int) (int, error) {
+			return v, nil
+		})
+		cancel()
+		select {
+		case _, ok := <-out:
+			So(ok, ShouldBeFalse)
+		case <-time.After(time.Second):
+			So("output never closed", ShouldBeEmpty)
+		}
+	})
+}
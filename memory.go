@@ -0,0 +1,117 @@
+package gogo
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryBudget caps the total size of the results that are waiting to be
+// consumed across every pool that shares it, such as the stages of a pipeline.
+// A pool whose next result doesn't fit blocks until a consumer takes enough
+// results off some pool sharing the budget.
+type MemoryBudget struct {
+	sem *weightedSemaphore
+}
+
+// NewMemoryBudget returns a MemoryBudget of limit, in whatever unit the pools
+// sharing it measure their results in, typically bytes.
+func NewMemoryBudget(limit int64) *MemoryBudget {
+	return &MemoryBudget{
+		sem: newWeightedSemaphore(limit),
+	}
+}
+
+// InUse returns the total size of the results currently held against the
+// budget.
+func (b *MemoryBudget) InUse() int64 {
+	b.sem.mu.Lock()
+	defer b.sem.mu.Unlock()
+	return b.sem.used
+}
+
+// budgeted is a result staged for the feed along with the size it holds
+// against the budget.
+type budgeted[T any] struct {
+	res  Optional[T]
+	size int64
+}
+
+// budgetedFeed holds a pool's results against a MemoryBudget from the time
+// they are sent until a consumer takes them off the feed.
+type budgetedFeed[T any] struct {
+	budget *MemoryBudget
+	sizeOf func(Optional[T]) int64
+	staged chan budgeted[T]
+
+	mu    sync.Mutex
+	held  int           // Results of this pool held against the budget
+	empty chan struct{} // Closed when held next drops to zero
+}
+
+// stage reserves room for res, blocking until there is some, and queues it for
+// the feed. A pool holding nothing takes room without waiting: otherwise
+// upstream stages could fill the budget while a downstream one, the only way
+// to free it, waits for room. Once ctx is done res is queued without room.
+func (f *budgetedFeed[T]) stage(ctx context.Context, res Optional[T]) {
+	size := f.sizeOf(res)
+	for {
+		f.mu.Lock()
+		if f.held == 0 {
+			f.held++
+			f.mu.Unlock()
+			f.budget.sem.take(size)
+			break
+		}
+		empty := f.empty
+		f.mu.Unlock()
+		if f.budget.sem.acquireUnless(ctx, size, empty) {
+			f.mu.Lock()
+			f.held++
+			f.mu.Unlock()
+			break
+		}
+		if ctx.Err() != nil {
+			size = -1
+			break
+		}
+	}
+	f.staged <- budgeted[T]{res: res, size: size}
+}
+
+// forward moves staged results to feed, releasing their room as each one is
+// taken, and closes feed after the last.
+func (f *budgetedFeed[T]) forward(feed chan<- Optional[T], done func()) {
+	for item := range f.staged {
+		feed <- item.res
+		if item.size < 0 {
+			continue
+		}
+		f.mu.Lock()
+		if f.held--; f.held == 0 {
+			close(f.empty)
+			f.empty = make(chan struct{})
+		}
+		f.mu.Unlock()
+		f.budget.sem.release(item.size)
+	}
+	close(feed)
+	done()
+}
+
+// WithMemoryBudget holds the pool's results against budget until they are
+// consumed, with sizeOf giving the size of each. Workers whose result doesn't
+// fit wait for room, so a slow consumer at the end of a pipeline holds back
+// every stage sharing the budget rather than letting each buffer fill up. So
+// that the stages can't starve each other, a pool holding no results may
+// always hold one, even over budget. It doesn't apply to GoPtr.
+func (g *Pool[T]) WithMemoryBudget(budget *MemoryBudget, sizeOf func(Optional[T]) int64) *Pool[T] {
+	g.mustNotBeStarted()
+	g.memory = &budgetedFeed[T]{
+		budget: budget,
+		sizeOf: sizeOf,
+		staged: make(chan budgeted[T], cap(g.feed)),
+		empty:  make(chan struct{}),
+	}
+	g.feed = make(chan Optional[T]) // Results wait in staged instead
+	return g
+}
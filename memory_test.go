@@ -0,0 +1,75 @@
+package gogo
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMemoryBudget(t *testing.T) {
+	Convey("Given pools sharing a budget that nobody reads, producers should block once it is used up", t, func() {
+		budget := NewMemoryBudget(40)
+		var produced int32
+		newPool := func() *Pool[[]byte] {
+			return NewPool(2, 20, func(i int) func() ([]byte, error) {
+				return func() ([]byte, error) {
+					atomic.AddInt32(&produced, 1)
+					return make([]byte, 10), nil
+				}
+			}).WithMemoryBudget(budget, func(res Optional[[]byte]) int64 {
+				return int64(len(res.Result))
+			})
+		}
+		a, b := newPool(), newPool()
+		feedA, feedB := a.Go(), b.Go()
+		time.Sleep(50 * time.Millisecond)
+		// 4 results fit, and a pool holding none may go over by one
+		inUse := budget.InUse()
+		So(inUse, ShouldBeBetweenOrEqual, 40, 50)
+		// Each of the 4 workers holds one more, waiting for room
+		So(atomic.LoadInt32(&produced), ShouldEqual, inUse/10+4)
+
+		count := 0
+		for range feedA {
+			count++
+			So(budget.InUse(), ShouldBeLessThanOrEqualTo, 50)
+		}
+		for range feedB {
+			count++
+		}
+		So(count, ShouldEqual, 40)
+		So(budget.InUse(), ShouldEqual, 0)
+	})
+
+	Convey("Given a chain whose stages share a budget, the pipeline should still complete", t, func() {
+		budget := NewMemoryBudget(3)
+		one := func(Optional[int]) int64 { return 1 }
+		source := NewPool(2, 50, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		}).WithMemoryBudget(budget, one)
+		doubled := Chain(context.Background(), source, 2, func(ctx context.Context, v int) (int, error) {
+			return v * 2, nil
+		}).WithMemoryBudget(budget, one)
+		sum := 0
+		for res := range doubled.Go() {
+			sum += res.Result
+		}
+		So(sum, ShouldEqual, 2450)
+		So(budget.InUse(), ShouldEqual, 0)
+	})
+
+	Convey("Given a budgeted pool nobody reads, Wait should still finish", t, func() {
+		pool := NewPool(2, 20, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		}).WithMemoryBudget(NewMemoryBudget(2), func(Optional[int]) int64 { return 1 })
+		pool.Wait()
+		So(pool.closed, ShouldBeTrue)
+	})
+}
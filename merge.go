@@ -0,0 +1,35 @@
+package gogo
+
+import (
+	"sync"
+)
+
+// Merge starts every pool and fans their feeds into a single channel, which
+// closes once every pool's feed has been drained. Keep-alives are left out, so
+// the channel can hold every result of every pool and a caller that stops
+// reading early doesn't leave any goroutine blocked.
+func Merge[T any](pools ...*Pool[T]) <-chan Optional[T] {
+	total := 0
+	for _, pool := range pools {
+		total += pool.size
+	}
+	out := make(chan Optional[T], total)
+	wg := &sync.WaitGroup{}
+	wg.Add(len(pools))
+	for _, pool := range pools {
+		go func() {
+			defer wg.Done()
+			for res := range pool.Go() {
+				if res.isKeepAlive() {
+					continue
+				}
+				out <- res
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
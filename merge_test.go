@@ -0,0 +1,52 @@
+package gogo
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMerge(t *testing.T) {
+	shard := func(offset, size int) *Pool[int] {
+		return NewPool(2, size, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return offset + i, nil
+			}
+		})
+	}
+
+	Convey("Given pools of different sizes, Merge should deliver every result once", t, func() {
+		seen := map[int]bool{}
+		for res := range Merge(shard(0, 3), shard(100, 10), shard(200, 1)) {
+			So(seen[res.Result], ShouldBeFalse)
+			seen[res.Result] = true
+		}
+		So(seen, ShouldHaveLength, 14)
+		So(seen[0] && seen[109] && seen[200], ShouldBeTrue)
+	})
+
+	Convey("Given a caller that stops reading early, the pools should still finish", t, func() {
+		pools := []*Pool[int]{shard(0, 50), shard(100, 50)}
+		<-Merge(pools...)
+		done := make(chan struct{})
+		go func() {
+			for _, pool := range pools {
+				pool.Wait()
+			}
+			close(done)
+		}()
+		finished := false
+		select {
+		case <-done:
+			finished = true
+		case <-time.After(time.Second):
+		}
+		So(finished, ShouldBeTrue)
+	})
+
+	Convey("Given no pools, Merge should close straight away", t, func() {
+		_, ok := <-Merge[int]()
+		So(ok, ShouldBeFalse)
+	})
+}
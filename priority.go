@@ -0,0 +1,31 @@
+package gogo
+
+import (
+	"cmp"
+	"context"
+	"slices"
+)
+
+// PriorityTask is a task for NewPriorityPool. Tasks with a higher Priority are
+// dispatched first.
+type PriorityTask[T any] struct {
+	Priority int
+	Fn       func(ctx context.Context) (T, error)
+}
+
+// NewPriorityPool returns a pool that dispatches tasks highest Priority first,
+// and tasks of equal priority in the order they are given. Since every task is
+// known up front, they are simply sorted once rather than kept in a queue, and
+// the workers take them from the front of that order one at a time. A result's
+// Index is its task's position in that dispatch order, not in tasks.
+func NewPriorityPool[T any](ctx context.Context, concurrency int, tasks []PriorityTask[T]) *Pool[T] {
+	sorted := slices.Clone(tasks)
+	slices.SortStableFunc(sorted, func(a, b PriorityTask[T]) int {
+		return cmp.Compare(b.Priority, a.Priority)
+	})
+	pool := NewPoolContext(ctx, concurrency, len(sorted), func(i int) func(ctx context.Context) (T, error) {
+		return sorted[i].Fn
+	})
+	pool.inOrder = true
+	return pool
+}
@@ -0,0 +1,91 @@
+package gogo
+
+import (
+	"context"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPriorityPool(t *testing.T) {
+	Convey("Given tasks with priorities, the most important should start first", t, func() {
+		var mu sync.Mutex
+		var started []string
+		task := func(name string, priority int) PriorityTask[string] {
+			return PriorityTask[string]{
+				Priority: priority,
+				Fn: func(ctx context.Context) (string, error) {
+					mu.Lock()
+					started = append(started, name)
+					mu.Unlock()
+					return name, nil
+				},
+			}
+		}
+		pool := NewPriorityPool(context.Background(), 1, []PriorityTask[string]{
+			task("low", 1),
+			task("urgent", 10),
+			task("normal-a", 5),
+			task("high", 8),
+			task("normal-b", 5),
+		})
+		var results []string
+		for res := range pool.Go() {
+			results = append(results, res.Result)
+		}
+		So(started, ShouldResemble, []string{"urgent", "high", "normal-a", "normal-b", "low"})
+		So(results, ShouldResemble, started)
+	})
+
+	Convey("Given several workers and a slow task, tasks should still start by priority", t, func() {
+		var mu sync.Mutex
+		var started []int
+		tasks := make([]PriorityTask[int], 12)
+		for i := range tasks {
+			rank := len(tasks) - 1 - i // Given lowest priority first
+			tasks[i] = PriorityTask[int]{
+				Priority: 100 - rank,
+				Fn: func(ctx context.Context) (int, error) {
+					mu.Lock()
+					started = append(started, rank)
+					mu.Unlock()
+					if rank == 0 {
+						time.Sleep(50 * time.Millisecond)
+					} else {
+						time.Sleep(2 * time.Millisecond)
+					}
+					return rank, nil
+				},
+			}
+		}
+		NewPriorityPool(context.Background(), 3, tasks).Wait()
+		So(started, ShouldHaveLength, len(tasks))
+		// Workers that start at the same time may log out of order, but never
+		// by more than the number of workers
+		for pos, rank := range started {
+			So(rank, ShouldBeLessThan, pos+3)
+		}
+	})
+
+	Convey("Given extreme priorities, they should be ordered without overflowing", t, func() {
+		task := func(priority int) PriorityTask[int] {
+			return PriorityTask[int]{
+				Priority: priority,
+				Fn: func(ctx context.Context) (int, error) {
+					return priority, nil
+				},
+			}
+		}
+		pool := NewPriorityPool(context.Background(), 1, []PriorityTask[int]{
+			task(math.MinInt), task(0), task(math.MaxInt),
+		})
+		var results []int
+		for res := range pool.Go() {
+			results = append(results, res.Result)
+		}
+		So(results, ShouldResemble, []int{math.MaxInt, 0, math.MinInt})
+	})
+}
@@ -0,0 +1,316 @@
+package gogo
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrFilterRejected is the error a Proc resolves to when its value was
+// filtered out.
+var ErrFilterRejected = errors.New("gogo: value rejected by filter")
+
+// IsFiltered reports whether err is, or wraps, ErrFilterRejected.
+func IsFiltered(err error) bool {
+	return errors.Is(err, ErrFilterRejected)
+}
+
+// ErrConditionNotMet is returned by WaitUntil when the result never satisfied
+// the condition.
+var ErrConditionNotMet = errors.New("gogo: condition not met")
+
+// Just returns a Proc that is already resolved to value, without starting a
+// goroutine. It lifts a plain value into a Proc pipeline.
+func Just[T any](ctx context.Context, value T) *Proc[T] {
+	return resolved(ctx, value, nil)
+}
+
+// Fail returns a Proc that is already resolved to err, without starting a
+// goroutine.
+func Fail[T any](ctx context.Context, err error) *Proc[T] {
+	var zero T
+	return resolved(ctx, zero, err)
+}
+
+func resolved[T any](ctx context.Context, res T, err error) *Proc[T] {
+	proc := &Proc[T]{
+		ctx: ctx,
+	}
+	proc.result.Store(&Optional[T]{
+		Result: res,
+		Error:  err,
+	})
+	proc.once.Do(func() {}) // Nothing left to run
+	proc.settled = true
+	return proc
+}
+
+// BindContext returns a Proc that resolves to p's result, but whose context is
+// ctx. p is not run again; if ctx is done before p resolves, the returned Proc
+// fails with ctx's error.
+//
+// Procs derived by combinators like OrElse or Catch inherit the context of
+// the Proc they derive from. If it is done before that Proc resolves, they
+// stop waiting and carry on as though it had failed with the context's error.
+// BindContext is how to derive under a different context instead.
+func (p *Proc[T]) BindContext(ctx context.Context) *Proc[T] {
+	return p.track(Lazy(ctx, p.ResultContext))
+}
+
+// derive starts fn as a Proc derived from p, under p's context.
+func (p *Proc[T]) derive(fn func(ctx context.Context) (T, error)) *Proc[T] {
+	return p.track(Lazy(p.Context(), fn))
+}
+
+// track counts child as derived from p, for WaitDerived, and starts it. Only
+// the count is kept, so a long-lived p doesn't hold on to what was derived
+// from it.
+func (p *Proc[T]) track(child *Proc[T]) *Proc[T] {
+	child.parent = p
+	p.reopen()
+	go child.Go()
+	return child
+}
+
+// reopen counts one more busy Proc derived from p, making p busy again if it
+// was idle. Locks are only ever taken from child to parent.
+func (p *Proc[T]) reopen() {
+	p.derivedMu.Lock()
+	defer p.derivedMu.Unlock()
+	if p.settled && p.busy == 0 && p.parent != nil {
+		p.parent.reopen()
+	}
+	p.busy++
+}
+
+// settle records that p has resolved, which makes it idle if nothing derived
+// from it is busy.
+func (p *Proc[T]) settle() {
+	p.derivedMu.Lock()
+	defer p.derivedMu.Unlock()
+	p.settled = true
+	if p.busy == 0 && p.parent != nil {
+		p.parent.childIdle()
+	}
+}
+
+// childIdle records that a Proc derived from p has gone idle.
+func (p *Proc[T]) childIdle() {
+	p.derivedMu.Lock()
+	defer p.derivedMu.Unlock()
+	p.busy--
+	if p.busy > 0 {
+		return
+	}
+	if p.idle != nil {
+		close(p.idle)
+		p.idle = nil
+	}
+	if p.settled && p.parent != nil {
+		p.parent.childIdle()
+	}
+}
+
+// WaitDerived waits for p and for every Proc derived from it by its
+// combinators, and from those in turn. Procs derived while it waits are
+// waited for too.
+func (p *Proc[T]) WaitDerived() {
+	p.Wait()
+	p.derivedMu.Lock()
+	if p.busy == 0 {
+		p.derivedMu.Unlock()
+		return
+	}
+	if p.idle == nil {
+		p.idle = make(chan struct{})
+	}
+	idle := p.idle
+	p.derivedMu.Unlock()
+	<-idle
+}
+
+// OrElse returns a Proc that resolves to p's result when p succeeds, and to
+// fallback with a nil error when p fails.
+func (p *Proc[T]) OrElse(fallback T) *Proc[T] {
+	return p.OrElseGet(func(error) T {
+		return fallback
+	})
+}
+
+// OrElseGet is like OrElse but computes the fallback from p's error. f is only
+// called when p fails.
+func (p *Proc[T]) OrElseGet(f func(error) T) *Proc[T] {
+	return p.derive(func(ctx context.Context) (T, error) {
+		res, err := p.ResultContext(ctx)
+		if err != nil {
+			return f(err), nil
+		}
+		return res, nil
+	})
+}
+
+// Tap returns a Proc that calls f with p's result and then resolves to that
+// same result unchanged. f runs exactly once no matter how many times the
+// returned Proc is awaited, which makes it a good place for logging or metrics.
+func (p *Proc[T]) Tap(f func(T, error)) *Proc[T] {
+	return p.derive(func(ctx context.Context) (T, error) {
+		res, err := p.ResultContext(ctx)
+		f(res, err)
+		return res, err
+	})
+}
+
+// Finally returns a Proc that calls f once p has resolved, successfully or
+// not, and then resolves to p's result unchanged. Unlike the other
+// combinators it waits for p even if p's context is done first, so f always
+// runs after p, which makes it the place to release what p used. f runs
+// exactly once no matter how many times the returned Proc is awaited.
+func (p *Proc[T]) Finally(f func()) *Proc[T] {
+	return p.derive(func(context.Context) (T, error) {
+		defer f()
+		return p.Result()
+	})
+}
+
+// MapMaybe returns a Proc that maps p's result through f and keeps it only if f
+// reports true. A dropped value resolves to ErrFilterRejected. If p fails its
+// error is passed through and f is not called.
+func (p *Proc[T]) MapMaybe(f func(T) (T, bool)) *Proc[T] {
+	return p.derive(func(ctx context.Context) (T, error) {
+		res, err := p.ResultContext(ctx)
+		if err != nil {
+			return res, err
+		}
+		mapped, ok := f(res)
+		if !ok {
+			var zero T
+			return zero, ErrFilterRejected
+		}
+		return mapped, nil
+	})
+}
+
+// Filter returns a Proc that resolves to p's result if keep reports true for
+// it, and fails with ErrFilterRejected otherwise. If p fails its error is
+// passed through and keep is not called.
+func (p *Proc[T]) Filter(keep func(T) bool) *Proc[T] {
+	return p.MapMaybe(func(res T) (T, bool) {
+		return res, keep(res)
+	})
+}
+
+// Catch returns a Proc that recovers from p's error by calling f with it. f
+// may return a recovered value or a new error. If p succeeds its result is
+// passed through and f is not called.
+func (p *Proc[T]) Catch(f func(error) (T, error)) *Proc[T] {
+	return p.derive(func(ctx context.Context) (T, error) {
+		res, err := p.ResultContext(ctx)
+		if err != nil {
+			return f(err)
+		}
+		return res, nil
+	})
+}
+
+// OnSuccess returns a Proc that runs f on p's result when p succeeds. If p
+// fails its error is passed through untouched and f is not called.
+func (p *Proc[T]) OnSuccess(f func(T) (T, error)) *Proc[T] {
+	return p.derive(func(ctx context.Context) (T, error) {
+		res, err := p.ResultContext(ctx)
+		if err != nil {
+			return res, err
+		}
+		return f(res)
+	})
+}
+
+// OnFailure is the counterpart of OnSuccess: it runs f with p's error when p
+// fails and leaves a successful result untouched. It behaves exactly like
+// Catch, and reads better next to OnSuccess.
+func (p *Proc[T]) OnFailure(f func(error) (T, error)) *Proc[T] {
+	return p.Catch(f)
+}
+
+// WaitUntil waits for p's result to satisfy cond. If it doesn't, p's function
+// is run again under p's context, and again, until a result does or timeout
+// has passed since WaitUntil was called. This suits Procs that poll a source
+// that changes over time; the function should pace its own polling. A Proc
+// that was already resolved, like one from Just, is only checked once. A
+// failed attempt ends the wait with its error, and running out of time or
+// attempts with ErrConditionNotMet. In both cases the last result is returned.
+func (p *Proc[T]) WaitUntil(cond func(T) bool, timeout time.Duration) (T, error) {
+	ctx, cancel := context.WithTimeout(p.Context(), timeout)
+	defer cancel()
+	attempt := p
+	var last T
+	for {
+		if attempt.WaitContext(ctx) != nil {
+			return last, ErrConditionNotMet
+		}
+		res, err := attempt.Result()
+		if err != nil {
+			return res, err
+		}
+		if cond(res) {
+			return res, nil
+		}
+		if p.fn == nil || ctx.Err() != nil {
+			return res, ErrConditionNotMet
+		}
+		last = res
+		attempt = p.WithContext(ctx)
+	}
+}
+
+// Delay returns a Proc that resolves to p's result, but not before d has
+// passed. Like one from Lazy, it doesn't start until the first Go, Result or
+// Wait, and the delay counts from then. A p from Lazy that hasn't started yet
+// is started once the delay is over, which is how Delay postpones work. A p
+// that is already running or resolved, like one from Go or Just, is never run
+// again: its result is simply held back until the delay has passed. If p's
+// context is done during the delay, the Proc fails with the context's error
+// without starting p.
+func (p *Proc[T]) Delay(d time.Duration) *Proc[T] {
+	return Lazy(p.Context(), func(ctx context.Context) (T, error) {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+		return p.ResultContext(ctx)
+	})
+}
+
+// Timeout is TimeoutWith failing with context.DeadlineExceeded.
+func (p *Proc[T]) Timeout(d time.Duration) *Proc[T] {
+	return p.TimeoutWith(d, context.DeadlineExceeded)
+}
+
+// TimeoutWith returns a Proc that resolves to p's result, or fails with
+// onTimeout if p hasn't resolved within d of TimeoutWith being called. p keeps
+// running either way. If p resolves just as the timeout fires, p's result
+// wins.
+func (p *Proc[T]) TimeoutWith(d time.Duration, onTimeout error) *Proc[T] {
+	timer := time.NewTimer(d)
+	return p.derive(func(ctx context.Context) (T, error) {
+		defer timer.Stop()
+		results := make(chan Optional[T], 1)
+		go func() {
+			res, err := p.ResultContext(ctx)
+			results <- Optional[T]{Result: res, Error: err}
+		}()
+		select {
+		case res := <-results:
+			return res.Result, res.Error
+		case <-timer.C:
+			if p.Done() {
+				return p.Result()
+			}
+			var zero T
+			return zero, onTimeout
+		}
+	})
+}
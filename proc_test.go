@@ -0,0 +1,677 @@
+package gogo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestProcCombinators(t *testing.T) {
+	Convey("Given a failing Proc, OrElse should resolve to the fallback", t, func() {
+		proc := Go(func() (int, error) {
+			return 0, errors.New("boom")
+		}).OrElse(7)
+		res, err := proc.Result()
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, 7)
+	})
+
+	Convey("Given a successful Proc, OrElse should keep its result", t, func() {
+		proc := Go(func() (int, error) {
+			return 42, nil
+		}).OrElse(7)
+		res, err := proc.Result()
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, 42)
+	})
+
+	Convey("Given a failing Proc, OrElseGet should compute the fallback from the error", t, func() {
+		proc := Go(func() (string, error) {
+			return "", errors.New("boom")
+		}).OrElseGet(func(err error) string {
+			return "recovered from " + err.Error()
+		})
+		res, err := proc.Result()
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, "recovered from boom")
+	})
+
+	Convey("Given a Proc, Tap should observe the result once and pass it through", t, func() {
+		calls := 0
+		var seen int
+		proc := Go(func() (int, error) {
+			return 42, nil
+		}).Tap(func(res int, err error) {
+			calls++
+			seen = res
+		})
+		for i := 0; i < 3; i++ {
+			res, err := proc.Result()
+			So(err, ShouldBeNil)
+			So(res, ShouldEqual, 42)
+		}
+		So(calls, ShouldEqual, 1)
+		So(seen, ShouldEqual, 42)
+	})
+
+	Convey("Given a failing Proc, Tap should see and keep the error", t, func() {
+		var seen error
+		proc := Go(func() (int, error) {
+			return 0, errors.New("boom")
+		}).Tap(func(res int, err error) {
+			seen = err
+		})
+		_, err := proc.Result()
+		So(err, ShouldNotBeNil)
+		So(seen, ShouldEqual, err)
+	})
+
+	Convey("Given MapMaybe keeping the value, the Proc should resolve to the mapped value", t, func() {
+		proc := Go(func() (int, error) {
+			return 4, nil
+		}).MapMaybe(func(v int) (int, bool) {
+			return v * 10, v%2 == 0
+		})
+		res, err := proc.Result()
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, 40)
+	})
+
+	Convey("Given MapMaybe dropping the value, the Proc should resolve to ErrFilterRejected", t, func() {
+		proc := Go(func() (int, error) {
+			return 3, nil
+		}).MapMaybe(func(v int) (int, bool) {
+			return v * 10, v%2 == 0
+		})
+		res, err := proc.Result()
+		So(err, ShouldEqual, ErrFilterRejected)
+		So(res, ShouldEqual, 0)
+	})
+
+	Convey("Given a dropped value, OrElse should fall back cleanly", t, func() {
+		res, err := Go(func() (int, error) {
+			return 3, nil
+		}).MapMaybe(func(v int) (int, bool) {
+			return v, false
+		}).OrElse(-1).Result()
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, -1)
+	})
+
+	Convey("Given a rate limited Proc, Catch should recover with a cached value", t, func() {
+		errRateLimited := errors.New("429")
+		proc := Go(func() (string, error) {
+			return "", errRateLimited
+		}).Catch(func(err error) (string, error) {
+			if errors.Is(err, errRateLimited) {
+				return "cached", nil
+			}
+			return "", err
+		})
+		res, err := proc.Result()
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, "cached")
+	})
+
+	Convey("Given an unexpected error, Catch should be able to re-raise it", t, func() {
+		proc := Go(func() (string, error) {
+			return "", errors.New("500")
+		}).Catch(func(err error) (string, error) {
+			return "", fmt.Errorf("upstream: %w", err)
+		})
+		_, err := proc.Result()
+		So(err.Error(), ShouldEqual, "upstream: 500")
+	})
+
+	Convey("Given a successful Proc, Catch should not be called", t, func() {
+		called := false
+		res, err := Go(func() (string, error) {
+			return "fresh", nil
+		}).Catch(func(err error) (string, error) {
+			called = true
+			return "cached", nil
+		}).Result()
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, "fresh")
+		So(called, ShouldBeFalse)
+	})
+
+	Convey("Given Just, the Proc should already be done with the value", t, func() {
+		proc := Just(context.Background(), 42)
+		So(proc.Done(), ShouldBeTrue)
+		res, err := proc.Result()
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, 42)
+		proc.Wait()
+	})
+
+	Convey("Given Fail, the Proc should already be done with the error", t, func() {
+		proc := Fail[int](context.Background(), errors.New("boom"))
+		So(proc.Done(), ShouldBeTrue)
+		_, err := proc.Result()
+		So(err.Error(), ShouldEqual, "boom")
+	})
+
+	Convey("Given Just, it should compose with other combinators", t, func() {
+		res, err := Fail[int](context.Background(), errors.New("boom")).OrElse(7).Result()
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, 7)
+	})
+
+	Convey("Given a successful Proc, OnSuccess should run on its result", t, func() {
+		res, err := Go(func() (int, error) {
+			return 20, nil
+		}).OnSuccess(func(v int) (int, error) {
+			return v + 1, nil
+		}).Result()
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, 21)
+	})
+
+	Convey("Given a failing Proc, OnSuccess should be skipped and the error kept", t, func() {
+		called := false
+		_, err := Go(func() (int, error) {
+			return 0, errors.New("boom")
+		}).OnSuccess(func(v int) (int, error) {
+			called = true
+			return v, nil
+		}).Result()
+		So(err.Error(), ShouldEqual, "boom")
+		So(called, ShouldBeFalse)
+	})
+
+	Convey("Given OnSuccess and OnFailure chained, only the matching branch should run", t, func() {
+		var branches []string
+		onSuccess := func(v int) (int, error) {
+			branches = append(branches, "success")
+			return v, nil
+		}
+		onFailure := func(err error) (int, error) {
+			branches = append(branches, "failure")
+			return -1, nil
+		}
+
+		res, err := Go(func() (int, error) {
+			return 0, errors.New("boom")
+		}).OnSuccess(onSuccess).OnFailure(onFailure).Result()
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, -1)
+		So(branches, ShouldResemble, []string{"failure"})
+
+		branches = nil
+		res, err = Go(func() (int, error) {
+			return 5, nil
+		}).OnSuccess(onSuccess).OnFailure(onFailure).Result()
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, 5)
+		So(branches, ShouldResemble, []string{"success"})
+	})
+
+	Convey("Given a Proc created with a context, Context should return it", t, func() {
+		ctx := context.WithValue(context.Background(), "reqID", "abc")
+		proc := GoContext(ctx, func(ctx context.Context) (string, error) {
+			return ctx.Value("reqID").(string), nil
+		})
+		So(proc.Context(), ShouldEqual, ctx)
+		res, err := proc.Result()
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, "abc")
+		So(Go(func() (int, error) { return 1, nil }).Context(), ShouldEqual, context.Background())
+	})
+
+	Convey("Given a Proc that timed out, WithContext should rerun it under a fresh context", t, func() {
+		var runs int32
+		slow := func(ctx context.Context) (int, error) {
+			atomic.AddInt32(&runs, 1)
+			select {
+			case <-ctx.Done():
+				return 0, ctx.Err()
+			case <-time.After(50 * time.Millisecond):
+				return 42, nil
+			}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		proc := GoContext(ctx, slow)
+		_, err := proc.Result()
+		So(err, ShouldEqual, context.DeadlineExceeded)
+
+		retry := proc.WithContext(context.Background())
+		So(retry.Context(), ShouldEqual, context.Background())
+		time.Sleep(20 * time.Millisecond)
+		So(atomic.LoadInt32(&runs), ShouldEqual, 1) // Not run until awaited
+		res, err := retry.Result()
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, 42)
+		So(atomic.LoadInt32(&runs), ShouldEqual, 2)
+	})
+
+	Convey("Given a resolved Proc, WithContext should keep its result", t, func() {
+		ctx := context.WithValue(context.Background(), "reqID", "abc")
+		proc := Just(context.Background(), 7).WithContext(ctx)
+		So(proc.Context(), ShouldEqual, ctx)
+		So(proc.Done(), ShouldBeTrue)
+		res, err := proc.Result()
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, 7)
+	})
+
+	Convey("Given a polling Proc, WaitUntil should rerun it until the condition holds", t, func() {
+		var polls int32
+		proc := Go(func() (string, error) {
+			if atomic.AddInt32(&polls, 1) < 3 {
+				return "pending", nil
+			}
+			return "ready", nil
+		})
+		res, err := proc.WaitUntil(func(status string) bool {
+			return status == "ready"
+		}, time.Second)
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, "ready")
+		So(atomic.LoadInt32(&polls), ShouldEqual, 3)
+	})
+
+	Convey("Given a polling Proc that never gets there, WaitUntil should time out", t, func() {
+		proc := Go(func() (string, error) {
+			time.Sleep(5 * time.Millisecond)
+			return "pending", nil
+		})
+		res, err := proc.WaitUntil(func(status string) bool {
+			return status == "ready"
+		}, 30*time.Millisecond)
+		So(err, ShouldEqual, ErrConditionNotMet)
+		So(res, ShouldEqual, "pending")
+	})
+
+	Convey("Given a resolved Proc, WaitUntil should check it once", t, func() {
+		_, err := Just(context.Background(), 1).WaitUntil(func(v int) bool {
+			return v > 1
+		}, time.Second)
+		So(err, ShouldEqual, ErrConditionNotMet)
+	})
+
+	Convey("Given a caller that gives up waiting, ResultContext should return while the Proc keeps running", t, func() {
+		var finished int32
+		proc := Go(func() (int, error) {
+			time.Sleep(50 * time.Millisecond)
+			atomic.StoreInt32(&finished, 1)
+			return 42, nil
+		})
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := proc.ResultContext(ctx)
+		So(err, ShouldEqual, context.DeadlineExceeded)
+		So(atomic.LoadInt32(&finished), ShouldEqual, 0)
+
+		res, err := proc.ResultContext(context.Background())
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, 42)
+		So(atomic.LoadInt32(&finished), ShouldEqual, 1)
+	})
+
+	Convey("Given a hanging Proc, WaitContext should give up once its context is done", t, func() {
+		release := make(chan struct{})
+		defer close(release)
+		proc := Go(func() (int, error) {
+			<-release
+			return 0, errors.New("failed")
+		})
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		So(proc.WaitContext(ctx), ShouldEqual, context.DeadlineExceeded)
+		So(proc.Done(), ShouldBeFalse)
+
+		failed := Fail[int](context.Background(), errors.New("failed"))
+		So(failed.WaitContext(ctx), ShouldBeNil) // Only the wait can fail
+	})
+
+	Convey("Given Finally awaited concurrently, f should run exactly once, after p", t, func() {
+		var calls int32
+		release := make(chan struct{})
+		errFailed := errors.New("failed")
+		proc := Go(func() (int, error) {
+			<-release
+			return 7, errFailed
+		})
+		var afterProc atomic.Bool
+		finished := proc.Finally(func() {
+			afterProc.Store(proc.Done())
+			atomic.AddInt32(&calls, 1)
+		})
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				finished.Wait()
+			}()
+		}
+		time.Sleep(10 * time.Millisecond)
+		So(atomic.LoadInt32(&calls), ShouldEqual, 0)
+		close(release)
+		wg.Wait()
+		res, err := finished.Result()
+		So(res, ShouldEqual, 7)
+		So(err, ShouldEqual, errFailed)
+		So(atomic.LoadInt32(&calls), ShouldEqual, 1)
+		So(afterProc.Load(), ShouldBeTrue)
+	})
+
+	Convey("Given a context done before p resolves, Finally should still wait for p", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		release := make(chan struct{})
+		proc := GoContext(ctx, func(context.Context) (int, error) {
+			<-release
+			return 1, nil
+		})
+		var ran atomic.Bool
+		finished := proc.Finally(func() { ran.Store(true) })
+		cancel()
+		time.Sleep(10 * time.Millisecond)
+		So(ran.Load(), ShouldBeFalse)
+		close(release)
+		res, err := finished.Result()
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, 1)
+		So(ran.Load(), ShouldBeTrue)
+	})
+
+	Convey("Given a Proc polled with TryResult, it should report the result only once available", t, func() {
+		release := make(chan struct{})
+		errFailed := errors.New("failed")
+		proc := Go(func() (int, error) {
+			<-release
+			return 3, errFailed
+		})
+		res, err, ok := proc.TryResult()
+		So(ok, ShouldBeFalse)
+		So(res, ShouldEqual, 0)
+		So(err, ShouldBeNil)
+		polled := make(chan int)
+		go func() {
+			// Poll while the result is written
+			for {
+				if res, _, ok := proc.TryResult(); ok {
+					polled <- res
+					return
+				}
+			}
+		}()
+		close(release)
+		So(<-polled, ShouldEqual, 3)
+		res, err, ok = proc.TryResult()
+		So(ok, ShouldBeTrue)
+		So(res, ShouldEqual, 3)
+		So(err, ShouldEqual, errFailed)
+
+		Convey("And it should not start a Lazy Proc", func() {
+			var runs atomic.Int32
+			lazy := Lazy(context.Background(), func(context.Context) (int, error) {
+				runs.Add(1)
+				return 1, nil
+			})
+			_, _, ok := lazy.TryResult()
+			So(ok, ShouldBeFalse)
+			time.Sleep(10 * time.Millisecond)
+			So(runs.Load(), ShouldEqual, 0)
+		})
+	})
+
+	Convey("Given a Lazy Proc, it should only run once awaited", t, func() {
+		var runs int32
+		proc := Lazy(context.Background(), func(ctx context.Context) (int, error) {
+			atomic.AddInt32(&runs, 1)
+			return 42, nil
+		})
+		time.Sleep(20 * time.Millisecond)
+		So(atomic.LoadInt32(&runs), ShouldEqual, 0)
+		So(proc.Done(), ShouldBeFalse)
+		res, err := proc.Result()
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, 42)
+		proc.Wait()
+		So(atomic.LoadInt32(&runs), ShouldEqual, 1)
+	})
+}
+
+func TestProcContextInheritance(t *testing.T) {
+	identity := func(v int) (int, error) { return v, nil }
+	transforms := map[string]func(p *Proc[int]) *Proc[int]{
+		"Tap":       func(p *Proc[int]) *Proc[int] { return p.Tap(func(int, error) {}) },
+		"MapMaybe":  func(p *Proc[int]) *Proc[int] { return p.MapMaybe(func(v int) (int, bool) { return v, true }) },
+		"Catch":     func(p *Proc[int]) *Proc[int] { return p.Catch(func(err error) (int, error) { return 0, err }) },
+		"OnSuccess": func(p *Proc[int]) *Proc[int] { return p.OnSuccess(identity) },
+		"OnFailure": func(p *Proc[int]) *Proc[int] { return p.OnFailure(func(err error) (int, error) { return 0, err }) },
+	}
+	for name, transform := range transforms {
+		Convey("Given a cancelled source context, "+name+" should stop waiting on the source", t, func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			release := make(chan struct{})
+			defer close(release)
+			source := GoContext(ctx, func(ctx context.Context) (int, error) {
+				<-release // Ignores its context
+				return 1, nil
+			})
+			derived := transform(source)
+			So(derived.Context(), ShouldEqual, ctx)
+			cancel()
+			_, err := derived.Result()
+			So(err, ShouldEqual, context.Canceled)
+			So(source.Done(), ShouldBeFalse)
+		})
+	}
+
+	Convey("Given a cancelled source context, OrElse should fall back as if the source had failed", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		release := make(chan struct{})
+		defer close(release)
+		derived := GoContext(ctx, func(ctx context.Context) (int, error) {
+			<-release
+			return 1, nil
+		}).OrElse(-1)
+		cancel()
+		res, err := derived.Result()
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, -1)
+	})
+
+	Convey("Given a Proc bound to a new context, derived Procs should inherit it", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		release := make(chan struct{})
+		defer close(release)
+		source := Go(func() (int, error) {
+			<-release
+			return 1, nil
+		})
+		bound := source.BindContext(ctx)
+		derived := bound.OnSuccess(identity)
+		So(bound.Context(), ShouldEqual, ctx)
+		So(derived.Context(), ShouldEqual, ctx)
+		cancel()
+		_, err := derived.Result()
+		So(err, ShouldEqual, context.Canceled)
+	})
+
+	Convey("Given a Proc bound to a context that stays live, it should resolve to the original result", t, func() {
+		var runs int32
+		source := Go(func() (int, error) {
+			atomic.AddInt32(&runs, 1)
+			return 7, nil
+		})
+		res, err := source.BindContext(context.Background()).Result()
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, 7)
+		So(atomic.LoadInt32(&runs), ShouldEqual, 1)
+	})
+}
+
+func TestWaitDerived(t *testing.T) {
+	Convey("Given three Procs derived from one source, WaitDerived should wait for all of them", t, func() {
+		var finished int32
+		slow := func(v int) (int, error) {
+			time.Sleep(20 * time.Millisecond)
+			atomic.AddInt32(&finished, 1)
+			return v, nil
+		}
+		source := Go(func() (int, error) {
+			return 1, nil
+		})
+		a := source.OnSuccess(slow)
+		b := source.Tap(func(int, error) {
+			time.Sleep(30 * time.Millisecond)
+			atomic.AddInt32(&finished, 1)
+		})
+		c := source.MapMaybe(func(v int) (int, bool) {
+			time.Sleep(10 * time.Millisecond)
+			atomic.AddInt32(&finished, 1)
+			return v, true
+		})
+		grandchild := a.OnSuccess(slow)
+
+		source.WaitDerived()
+		So(atomic.LoadInt32(&finished), ShouldEqual, 4)
+		for _, proc := range []*Proc[int]{source, a, b, c, grandchild} {
+			So(proc.Done(), ShouldBeTrue)
+		}
+
+		late := source.OnSuccess(slow)
+		source.WaitDerived()
+		So(late.Done(), ShouldBeTrue)
+		So(atomic.LoadInt32(&finished), ShouldEqual, 5)
+	})
+
+	Convey("Given a long-lived source, the Procs derived from it should not be kept", t, func() {
+		source := Just(context.Background(), 1)
+		for i := 0; i < 100; i++ {
+			_, err := source.OnSuccess(func(v int) (int, error) {
+				return v + i, nil
+			}).Tap(func(int, error) {}).Result()
+			So(err, ShouldBeNil)
+		}
+		// The Tap resolves a moment after its result is read
+		source.WaitDerived()
+		So(source.busy, ShouldEqual, 0)
+		So(source.idle, ShouldBeNil)
+	})
+
+	Convey("Given a grandchild derived after its parent resolved, WaitDerived should still wait for it", t, func() {
+		source := Just(context.Background(), 1)
+		child := source.OrElse(0)
+		child.Wait()
+		release := make(chan struct{})
+		grandchild := child.Tap(func(int, error) {
+			<-release
+		})
+		time.AfterFunc(20*time.Millisecond, func() { close(release) })
+		source.WaitDerived()
+		So(grandchild.Done(), ShouldBeTrue)
+	})
+}
+
+func TestTimeoutWith(t *testing.T) {
+	errTooSlow := errors.New("too slow")
+
+	Convey("Given a Proc that takes longer than the timeout, TimeoutWith should fail with the custom error", t, func() {
+		res, err := Go(func() (int, error) {
+			time.Sleep(100 * time.Millisecond)
+			return 1, nil
+		}).TimeoutWith(10*time.Millisecond, errTooSlow).Result()
+		So(err, ShouldEqual, errTooSlow)
+		So(res, ShouldEqual, 0)
+	})
+
+	Convey("Given a Proc that takes longer than a plain Timeout, it should fail with the deadline error", t, func() {
+		_, err := Go(func() (int, error) {
+			time.Sleep(100 * time.Millisecond)
+			return 1, nil
+		}).Timeout(10 * time.Millisecond).Result()
+		So(err, ShouldEqual, context.DeadlineExceeded)
+	})
+
+	Convey("Given a Proc that beats the timeout, TimeoutWith should keep its result", t, func() {
+		res, err := Go(func() (int, error) {
+			return 42, nil
+		}).TimeoutWith(time.Second, errTooSlow).Result()
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, 42)
+	})
+
+	Convey("Given a Proc already resolved when the timeout fires, its result should win", t, func() {
+		source := Just(context.Background(), 7)
+		res, err := source.TimeoutWith(0, errTooSlow).Result()
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, 7)
+	})
+}
+
+func TestDelay(t *testing.T) {
+	Convey("Given a delayed lazy Proc, it should run once the delay has passed", t, func() {
+		var ran atomic.Int32
+		proc := Lazy(context.Background(), func(ctx context.Context) (int, error) {
+			ran.Add(1)
+			return 42, nil
+		}).Delay(50 * time.Millisecond)
+		time.Sleep(20 * time.Millisecond)
+		So(ran.Load(), ShouldEqual, 0) // Not started yet
+
+		start := time.Now()
+		res, err := proc.Result()
+		So(time.Since(start), ShouldBeGreaterThanOrEqualTo, 50*time.Millisecond)
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, 42)
+		So(ran.Load(), ShouldEqual, 1)
+	})
+
+	Convey("Given a context cancelled during the delay, the function should not run", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		var ran atomic.Int32
+		proc := Lazy(ctx, func(ctx context.Context) (int, error) {
+			ran.Add(1)
+			return 42, nil
+		}).Delay(time.Hour)
+		time.AfterFunc(20*time.Millisecond, cancel)
+		_, err := proc.Result()
+		So(err, ShouldEqual, context.Canceled)
+		So(ran.Load(), ShouldEqual, 0)
+	})
+
+	Convey("Given a resolved Proc, Delay should resolve to its result after the delay", t, func() {
+		start := time.Now()
+		res, err := Just(context.Background(), "done").Delay(30 * time.Millisecond).Result()
+		So(time.Since(start), ShouldBeGreaterThanOrEqualTo, 30*time.Millisecond)
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, "done")
+	})
+
+	Convey("Given a Proc that already started, Delay should not run its function again", t, func() {
+		var runs atomic.Int32
+		proc := Go(func() (int, error) {
+			runs.Add(1)
+			return 7, nil
+		})
+		delayed := proc.Delay(20 * time.Millisecond)
+		res, err := delayed.Result()
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, 7)
+		So(runs.Load(), ShouldEqual, 1)
+	})
+
+	Convey("Given a lazy Proc run both directly and delayed, its function should run once", t, func() {
+		var runs atomic.Int32
+		proc := Lazy(context.Background(), func(ctx context.Context) (int, error) {
+			runs.Add(1)
+			return 7, nil
+		})
+		delayed := proc.Delay(20 * time.Millisecond)
+		So(proc.Await(), ShouldBeNil)
+		So(delayed.Await(), ShouldBeNil)
+		So(runs.Load(), ShouldEqual, 1)
+	})
+}
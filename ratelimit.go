@@ -0,0 +1,72 @@
+package gogo
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// RateLimiter spaces out events so that no more than a set number happen per
+// second. It is safe for concurrent use and can be shared between pools and
+// Procs.
+type RateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing rps events per second. Like
+// WithRateLimit, it doesn't limit anything if rps is zero or less.
+func NewRateLimiter(rps float64) *RateLimiter {
+	if rps <= 0 {
+		return &RateLimiter{}
+	}
+	return &RateLimiter{
+		interval: time.Duration(float64(time.Second) / rps),
+	}
+}
+
+// Wait blocks until the next slot is available, or returns ctx.Err() if ctx
+// is done first.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := time.Until(at)
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// WithRateLimit caps how many tasks the pool starts per second, independently
+// of how many may run at once. Cancelling the pool interrupts a pending wait.
+func (g *Pool[T]) WithRateLimit(rps float64) *Pool[T] {
+	g.mustNotBeStarted()
+	if rps > 0 {
+		g.limiter = NewRateLimiter(rps)
+	}
+	return g
+}
+
+// AcquireToken returns a Proc that resolves once limiter allows another event,
+// or to ctx.Err() if ctx is done first. It lets a rate limit be awaited like
+// any other async step, and the Proc has ctx as its Context.
+func AcquireToken(ctx context.Context, limiter *RateLimiter) *Proc[struct{}] {
+	return GoContext(ctx, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, limiter.Wait(ctx)
+	})
+}
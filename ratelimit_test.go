@@ -0,0 +1,82 @@
+package gogo
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRateLimit(t *testing.T) {
+	Convey("Given a rate limited pool, tasks should start no faster than the limit", t, func() {
+		var mu sync.Mutex
+		var starts []time.Time
+		pool := NewPool(10, 5, func(i int) func() (int, error) {
+			return func() (int, error) {
+				mu.Lock()
+				starts = append(starts, time.Now())
+				mu.Unlock()
+				return i, nil
+			}
+		}).WithRateLimit(20)
+		pool.Wait()
+		So(starts, ShouldHaveLength, 5)
+		// Five starts at 20 per second span at least four 50ms intervals
+		So(starts[4].Sub(starts[0]), ShouldBeGreaterThanOrEqualTo, 190*time.Millisecond)
+	})
+
+	Convey("Given a cancelled rate limited pool, dispatch should not wait out the limiter", t, func() {
+		pool := NewPool(1, 100, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		}).WithRateLimit(1)
+		pool.Go()
+		time.Sleep(50 * time.Millisecond)
+		start := time.Now()
+		pool.Cancel()
+		pool.Wait()
+		So(time.Since(start), ShouldBeLessThan, 500*time.Millisecond)
+	})
+}
+
+func TestAcquireToken(t *testing.T) {
+	Convey("Given a shared limiter, AcquireToken Procs should resolve spaced out by the rate", t, func() {
+		limiter := NewRateLimiter(10)
+		start := time.Now()
+		var procs []*Proc[struct{}]
+		for i := 0; i < 3; i++ {
+			procs = append(procs, AcquireToken(context.Background(), limiter))
+		}
+		for _, proc := range procs {
+			_, err := proc.Result()
+			So(err, ShouldBeNil)
+		}
+		// The first token is free, the next two are 100ms apart
+		So(time.Since(start), ShouldBeGreaterThanOrEqualTo, 190*time.Millisecond)
+	})
+
+	Convey("Given a cancelled context, AcquireToken should resolve to the context error", t, func() {
+		limiter := NewRateLimiter(0.1)
+		So(limiter.Wait(context.Background()), ShouldBeNil)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		token := AcquireToken(ctx, limiter)
+		So(token.Context(), ShouldEqual, ctx)
+		_, err := token.Result()
+		So(err, ShouldEqual, context.DeadlineExceeded)
+	})
+
+	Convey("Given a rate of zero or less, the limiter should not hold anything back", t, func() {
+		for _, rps := range []float64{0, -5} {
+			limiter := NewRateLimiter(rps)
+			start := time.Now()
+			for i := 0; i < 100; i++ {
+				So(limiter.Wait(context.Background()), ShouldBeNil)
+			}
+			So(time.Since(start), ShouldBeLessThan, 50*time.Millisecond)
+		}
+	})
+}
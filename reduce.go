@@ -0,0 +1,32 @@
+package gogo
+
+import (
+	"context"
+)
+
+// ParallelReduce folds items into a single value using up to concurrency
+// goroutines. items is split into concurrency contiguous partitions, each
+// folded from identity with combine, and the partial results are then merged
+// left to right with merge. For the result to match a sequential fold, merge
+// must be associative and identity must be its identity element; combine
+// doesn't need to be commutative, as order is kept. If ctx is done before
+// every partition was folded, the result only covers those that were.
+func ParallelReduce[T any, A any](ctx context.Context, concurrency int, items []T, identity A, combine func(A, T) A, merge func(A, A) A) A {
+	partitions := min(max(concurrency, 1), len(items))
+	pool := NewPoolContext(ctx, partitions, partitions, func(p int) func(ctx context.Context) (A, error) {
+		part := items[p*len(items)/partitions : (p+1)*len(items)/partitions]
+		return func(ctx context.Context) (A, error) {
+			acc := identity
+			for _, item := range part {
+				acc = combine(acc, item)
+			}
+			return acc, nil
+		}
+	})
+	partials, _ := pool.Results()
+	acc := identity
+	for _, partial := range partials {
+		acc = merge(acc, partial)
+	}
+	return acc
+}
@@ -0,0 +1,37 @@
+package gogo
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParallelReduce(t *testing.T) {
+	Convey("Given a large slice, ParallelReduce should sum it like a sequential loop", t, func() {
+		items := make([]int, 1000003)
+		want := 0
+		for i := range items {
+			items[i] = i % 97
+			want += items[i]
+		}
+		add := func(a, b int) int { return a + b }
+		So(ParallelReduce(context.Background(), 8, items, 0, add, add), ShouldEqual, want)
+	})
+
+	Convey("Given an order sensitive fold, ParallelReduce should keep the items in order", t, func() {
+		words := strings.Fields("the quick brown fox jumps over the lazy dog")
+		joined := ParallelReduce(context.Background(), 4, words, "", func(acc string, word string) string {
+			return acc + word[:1]
+		}, func(a, b string) string {
+			return a + b
+		})
+		So(joined, ShouldEqual, "tqbfjotld")
+	})
+
+	Convey("Given no items, ParallelReduce should return the identity", t, func() {
+		add := func(a, b int) int { return a + b }
+		So(ParallelReduce(context.Background(), 4, nil, 0, add, add), ShouldEqual, 0)
+	})
+}
@@ -0,0 +1,114 @@
+package gogo
+
+import (
+	"container/heap"
+	"sync"
+)
+
+// indexHeap is a min-heap of task indices.
+type indexHeap []int
+
+func (h indexHeap) Len() int           { return len(h) }
+func (h indexHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h indexHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *indexHeap) Push(x any)        { *h = append(*h, x.(int)) }
+func (h *indexHeap) Pop() any {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+// reorderBuffer holds back results that complete ahead of their turn and
+// releases them in index order, but never holds more than window at a time.
+type reorderBuffer[T any] struct {
+	mu      sync.Mutex
+	window  int
+	next    int
+	indices indexHeap
+	pending map[int]Optional[T]
+}
+
+func newReorderBuffer[T any](window int) *reorderBuffer[T] {
+	return &reorderBuffer[T]{
+		window:  window,
+		pending: make(map[int]Optional[T]),
+	}
+}
+
+// push hands over the result of task i, sending whatever can be released.
+func (b *reorderBuffer[T]) push(i int, res Optional[T], send func(Optional[T])) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if i < b.next {
+		// Its turn has already been given up, don't hold it back any longer
+		send(res)
+		return
+	}
+	b.pending[i] = res
+	heap.Push(&b.indices, i)
+	if len(b.indices) > b.window {
+		// Over the window, give up waiting for the gap and skip ahead to the
+		// lowest index we do have
+		b.next = b.indices[0]
+	}
+	b.release(send)
+}
+
+// skip records that task i has nothing to send, so its turn can pass.
+func (b *reorderBuffer[T]) skip(i int, send func(Optional[T])) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if i < b.next {
+		return
+	}
+	heap.Push(&b.indices, i)
+	b.release(send)
+}
+
+// release sends the run of results starting at next. Must hold mu.
+func (b *reorderBuffer[T]) release(send func(Optional[T])) {
+	for len(b.indices) > 0 && b.indices[0] == b.next {
+		i := heap.Pop(&b.indices).(int)
+		if res, ok := b.pending[i]; ok {
+			send(res)
+			delete(b.pending, i)
+		}
+		b.next++
+	}
+}
+
+// flush sends everything still held back, in index order.
+func (b *reorderBuffer[T]) flush(send func(Optional[T])) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for len(b.indices) > 0 {
+		b.next = b.indices[0]
+		b.release(send)
+	}
+}
+
+// WithReorderWindow makes the feed deliver results close to task index order.
+// Results that finish ahead of their turn are held back, but no more than n
+// at a time; once more than n are waiting the feed stops waiting for the
+// missing ones and moves on. A window of 0 keeps completion order, and a
+// window as large as the pool gives full index order at the cost of holding
+// back every result behind a slow task.
+func (g *Pool[T]) WithReorderWindow(n int) *Pool[T] {
+	g.mustNotBeStarted()
+	if n > 0 {
+		g.reorder = newReorderBuffer[T](n)
+	} else {
+		g.reorder = nil
+	}
+	return g
+}
+
+// WithOrderedResults makes the feed deliver results strictly in task index
+// order, while still streaming them as soon as their turn comes. Results that
+// finish early are held until every task before them has been delivered, so a
+// slow task holds up everything after it.
+func (g *Pool[T]) WithOrderedResults() *Pool[T] {
+	return g.WithReorderWindow(g.size)
+}
@@ -0,0 +1,80 @@
+package gogo
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// finishInOrder builds a pool whose tasks complete in the given order of
+// indices, regardless of when they are started.
+func finishInOrder(order []int) *Pool[int] {
+	rank := make([]int, len(order))
+	for r, i := range order {
+		rank[i] = r
+	}
+	return NewPool(len(order), len(order), func(i int) func() (int, error) {
+		return func() (int, error) {
+			time.Sleep(time.Duration(rank[i]) * 30 * time.Millisecond)
+			return i, nil
+		}
+	})
+}
+
+func feedIndices(pool *Pool[int]) []int {
+	var out []int
+	for res := range pool.Go() {
+		out = append(out, res.Result)
+	}
+	return out
+}
+
+func TestReorderWindow(t *testing.T) {
+	Convey("Given a window as large as the pool, results should be fully ordered", t, func() {
+		pool := finishInOrder([]int{5, 4, 3, 2, 1, 0}).WithReorderWindow(6)
+		So(feedIndices(pool), ShouldResemble, []int{0, 1, 2, 3, 4, 5})
+	})
+
+	Convey("Given a window of 0, results should keep completion order", t, func() {
+		pool := finishInOrder([]int{5, 4, 3, 2, 1, 0}).WithReorderWindow(0)
+		So(feedIndices(pool), ShouldResemble, []int{5, 4, 3, 2, 1, 0})
+	})
+
+	Convey("Given a window of 2, no more than 2 results should be held back", t, func() {
+		pool := finishInOrder([]int{5, 4, 3, 2, 1, 0}).WithReorderWindow(2)
+		So(feedIndices(pool), ShouldResemble, []int{3, 4, 5, 2, 1, 0})
+	})
+
+	Convey("Given a shuffled completion order and a window of 1, gaps should be filled when possible", t, func() {
+		pool := finishInOrder([]int{1, 3, 0, 2, 5, 4}).WithReorderWindow(1)
+		So(feedIndices(pool), ShouldResemble, []int{1, 0, 2, 3, 4, 5})
+	})
+}
+
+func TestOrderedResults(t *testing.T) {
+	Convey("Given tasks finishing out of order, WithOrderedResults should deliver them in index order", t, func() {
+		pool := finishInOrder([]int{2, 0, 4, 1, 5, 3}).WithOrderedResults()
+		So(feedIndices(pool), ShouldResemble, []int{0, 1, 2, 3, 4, 5})
+	})
+
+	Convey("Given a slow first task, WithOrderedResults should stream the rest once it is done", t, func() {
+		pool := NewPool(4, 4, func(i int) func() (time.Time, error) {
+			return func() (time.Time, error) {
+				if i == 0 {
+					time.Sleep(100 * time.Millisecond)
+				}
+				return time.Now(), nil
+			}
+		}).WithOrderedResults()
+		start := time.Now()
+		var received []time.Duration
+		var finished []time.Time
+		for res := range pool.Go() {
+			received = append(received, time.Since(start))
+			finished = append(finished, res.Result)
+		}
+		So(received[0], ShouldBeGreaterThanOrEqualTo, 100*time.Millisecond)
+		So(finished[1].Before(finished[0]), ShouldBeTrue)
+	})
+}
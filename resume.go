@@ -0,0 +1,27 @@
+package gogo
+
+import (
+	"maps"
+)
+
+// WithCompletedSet resumes a pool from a checkpoint: the tasks whose index is
+// set in done are taken as completed by an earlier run, so they are neither run
+// nor sent on the feed, though they do count towards OnProgress. Persisting the
+// indices of delivered results and passing them back in on restart makes a
+// long batch job resumable after a crash. done is copied.
+func (g *Pool[T]) WithCompletedSet(done map[int]bool) *Pool[T] {
+	g.mustNotBeStarted()
+	g.resumeFrom = maps.Clone(done)
+	return g
+}
+
+// resumed reports whether task i was completed by an earlier run, and if so
+// accounts for it as though it had just finished.
+func (g *Pool[T]) resumed(i int) bool {
+	if !g.resumeFrom[i] {
+		return false
+	}
+	g.skip(i)
+	g.progress()
+	return true
+}
@@ -0,0 +1,38 @@
+package gogo
+
+import (
+	"sync/atomic"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCompletedSet(t *testing.T) {
+	Convey("Given a pool resumed from a checkpoint, completed tasks should be neither run nor delivered", t, func() {
+		runs := make([]int32, 10)
+		done := map[int]bool{0: true, 3: true, 4: true, 9: true, 7: false}
+		pool := NewPool(3, 10, func(i int) func() (int, error) {
+			return func() (int, error) {
+				atomic.AddInt32(&runs[i], 1)
+				return i, nil
+			}
+		}).WithCompletedSet(done).WithOrderedResults()
+		var progress int
+		pool.OnProgress(func(completed, total int) {
+			progress = completed
+		})
+		var delivered []int
+		for res := range pool.Go() {
+			delivered = append(delivered, res.Result)
+		}
+		So(delivered, ShouldResemble, []int{1, 2, 5, 6, 7, 8})
+		for i, n := range runs {
+			if done[i] {
+				So(n, ShouldEqual, 0)
+			} else {
+				So(n, ShouldEqual, 1)
+			}
+		}
+		So(progress, ShouldEqual, 10)
+	})
+}
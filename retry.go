@@ -0,0 +1,45 @@
+package gogo
+
+import (
+	"time"
+)
+
+type retryPolicy struct {
+	max     int
+	backoff func(attempt int) time.Duration
+}
+
+// WithRetries runs a task that fails again, up to max more times, before its
+// last error is delivered. Retries happen in place rather than being queued
+// behind the other tasks: the task's worker waits backoff(attempt) and runs it
+// again, attempt being the number of runs so far, and holds on to its slot in
+// the meantime, so a long backoff leaves the pool one worker short while it
+// lasts. A nil backoff retries straight away. A task that panics isn't
+// retried, see WithWorkerRestart, and neither is one once the pool is
+// cancelled. The task's function is simply called again, so it must be safe
+// to; a Chain's tasks aren't, since each run takes a new result from the
+// source. Optional.Attempts says how many runs a result took.
+func (g *Pool[T]) WithRetries(max int, backoff func(attempt int) time.Duration) *Pool[T] {
+	g.mustNotBeStarted()
+	g.retries = &retryPolicy{max: max, backoff: backoff}
+	return g
+}
+
+// retry reports whether a task whose attempts-th run failed with err should be
+// run again, waiting out the backoff first on the calling worker.
+func (g *Pool[T]) retry(attempts int, err error) bool {
+	if g.retries == nil || err == nil || err == errSkip || attempts > g.retries.max || g.stopping() {
+		return false
+	}
+	if g.retries.backoff == nil {
+		return true
+	}
+	timer := time.NewTimer(g.retries.backoff(attempts))
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return !g.stopping()
+	case <-g.ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,86 @@
+package gogo
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRetries(t *testing.T) {
+	Convey("Given a pool with retries, a task should run until it succeeds", t, func() {
+		var runs [4]atomic.Int32
+		var mu sync.Mutex
+		var waits []int
+		pool := NewPool(2, 4, func(i int) func() (int, error) {
+			return func() (int, error) {
+				// Task i fails its first i runs
+				if int(runs[i].Add(1)) <= i {
+					return 0, errors.New("flaky")
+				}
+				return i, nil
+			}
+		}).WithRetries(3, func(attempt int) time.Duration {
+			mu.Lock()
+			waits = append(waits, attempt)
+			mu.Unlock()
+			return time.Millisecond
+		})
+		results := map[int]Optional[int]{}
+		for res := range pool.Go() {
+			results[res.Index] = res
+		}
+		for i := range 4 {
+			So(results[i].Error, ShouldBeNil)
+			So(results[i].Attempts, ShouldEqual, i+1)
+		}
+		// 1 + 2 + 3 retries
+		So(waits, ShouldHaveLength, 6)
+		So(pool.Stats().Succeeded, ShouldEqual, 4)
+	})
+
+	Convey("Given a task that keeps failing, its last error should be delivered after the retries", t, func() {
+		var runs atomic.Int32
+		errLast := errors.New("last")
+		pool := NewPool(1, 1, func(i int) func() (int, error) {
+			return func() (int, error) {
+				if runs.Add(1) == 3 {
+					return 0, errLast
+				}
+				return 0, errors.New("earlier")
+			}
+		}).WithRetries(2, nil)
+		res := <-pool.Go()
+		So(res.Error, ShouldEqual, errLast)
+		So(res.Attempts, ShouldEqual, 3)
+		So(pool.Stats().Failed, ShouldEqual, 1)
+	})
+
+	Convey("Given a pool cancelled during a backoff, the task should stop retrying", t, func() {
+		var runs atomic.Int32
+		pool := NewPool(1, 1, func(i int) func() (int, error) {
+			return func() (int, error) {
+				runs.Add(1)
+				return 0, errors.New("failed")
+			}
+		}).WithRetries(5, func(int) time.Duration {
+			return time.Hour
+		})
+		time.AfterFunc(20*time.Millisecond, pool.Cancel)
+		start := time.Now()
+		res := <-pool.Go()
+		So(time.Since(start), ShouldBeLessThan, time.Second)
+		So(res.Attempts, ShouldEqual, 1)
+		So(runs.Load(), ShouldEqual, 1)
+	})
+
+	Convey("Given a pool without retries, each result should take one attempt", t, func() {
+		pool := NewPool(2, 3, failOdd)
+		for res := range pool.Go() {
+			So(res.Attempts, ShouldEqual, 1)
+		}
+	})
+}
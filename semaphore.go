@@ -0,0 +1,143 @@
+package gogo
+
+import (
+	"context"
+	"sync"
+)
+
+// semaphore limits how many tasks run at once. Unlike a buffered channel it
+// costs a single uncontended lock per acquire and release.
+type semaphore struct {
+	mu   sync.Mutex
+	cond sync.Cond
+	size int
+	used int
+}
+
+func newSemaphore(size int) *semaphore {
+	s := &semaphore{size: size}
+	s.cond.L = &s.mu
+	return s
+}
+
+// acquire takes a slot, blocking until one is free. It returns false without
+// taking a slot once ctx is done; watch must have been called with ctx for a
+// blocked acquire to notice.
+func (s *semaphore) acquire(ctx context.Context) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for s.used >= s.size && ctx.Err() == nil {
+		s.cond.Wait()
+	}
+	if ctx.Err() != nil {
+		// We may have been woken for a free slot, pass it on
+		s.cond.Signal()
+		return false
+	}
+	s.used++
+	return true
+}
+
+func (s *semaphore) release() {
+	s.mu.Lock()
+	s.used--
+	s.mu.Unlock()
+	s.cond.Signal()
+}
+
+// watch wakes blocked acquires when ctx is done. The returned function stops
+// watching.
+func (s *semaphore) watch(ctx context.Context) func() bool {
+	return context.AfterFunc(ctx, func() {
+		s.mu.Lock()
+		s.mu.Unlock()
+		s.cond.Broadcast()
+	})
+}
+
+// weightedSemaphore limits the total weight of the tasks running at once.
+// Waiters are served in order, so a heavy task isn't starved by lighter ones
+// that keep fitting in around it.
+type weightedSemaphore struct {
+	mu      sync.Mutex
+	size    int64
+	used    int64
+	waiters []weightedWaiter
+}
+
+type weightedWaiter struct {
+	weight int64
+	ready  chan struct{}
+}
+
+func newWeightedSemaphore(size int64) *weightedSemaphore {
+	return &weightedSemaphore{size: size}
+}
+
+// acquire takes weight, blocking until it fits. A weight larger than the whole
+// semaphore waits until it can have all of it. It returns false without taking
+// anything once ctx is done.
+func (s *weightedSemaphore) acquire(ctx context.Context, weight int64) bool {
+	return s.acquireUnless(ctx, weight, nil)
+}
+
+// acquireUnless is acquire that also gives up once abort is closed.
+func (s *weightedSemaphore) acquireUnless(ctx context.Context, weight int64, abort <-chan struct{}) bool {
+	weight = min(weight, s.size)
+	s.mu.Lock()
+	if len(s.waiters) == 0 && s.used+weight <= s.size {
+		s.used += weight
+		s.mu.Unlock()
+		return true
+	}
+	ready := make(chan struct{})
+	s.waiters = append(s.waiters, weightedWaiter{weight: weight, ready: ready})
+	s.mu.Unlock()
+
+	select {
+	case <-ready:
+		return true
+	case <-ctx.Done():
+	case <-abort:
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case <-ready:
+		// Acquired just as it gave up, give it back
+		s.used -= weight
+	default:
+		for i, w := range s.waiters {
+			if w.ready == ready {
+				s.waiters = append(s.waiters[:i], s.waiters[i+1:]...)
+				break
+			}
+		}
+	}
+	s.wake()
+	return false
+}
+
+// take takes weight straight away, even if that goes over the semaphore's
+// size; later acquires wait until enough is released.
+func (s *weightedSemaphore) take(weight int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.used += min(weight, s.size)
+}
+
+func (s *weightedSemaphore) release(weight int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.used -= min(weight, s.size)
+	s.wake()
+}
+
+// wake hands the freed weight to waiters, in order. Must hold mu.
+func (s *weightedSemaphore) wake() {
+	for len(s.waiters) > 0 && s.used+s.waiters[0].weight <= s.size {
+		s.used += s.waiters[0].weight
+		close(s.waiters[0].ready)
+		s.waiters = s.waiters[1:]
+	}
+}
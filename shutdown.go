@@ -0,0 +1,85 @@
+package gogo
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// gracefulShutdown lets a cancelled pool's running tasks finish, up to a
+// timeout, before their context is cancelled.
+type gracefulShutdown struct {
+	timeout  time.Duration
+	once     sync.Once
+	mu       sync.Mutex
+	timer    *time.Timer
+	finished bool
+	forced   bool
+	running  int // Tasks still running when the timeout fired
+	draining atomic.Bool
+	drain    chan struct{} // Closed along with draining being set
+}
+
+// begin stops the pool launching tasks and arms the force cancel, which counts
+// the tasks still running and cancels them. Only the first call does anything.
+func (s *gracefulShutdown) begin(running func() int, cancel func()) {
+	s.once.Do(func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.finished {
+			return
+		}
+		s.draining.Store(true)
+		close(s.drain)
+		s.timer = time.AfterFunc(s.timeout, func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if s.finished {
+				return
+			}
+			s.forced = true
+			s.running = running()
+			cancel()
+		})
+	})
+}
+
+// finish disarms the force cancel once the pool is done.
+func (s *gracefulShutdown) finish() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.finished = true
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+}
+
+// WithGracefulShutdown makes Cancel graceful: the pool stops launching tasks
+// straight away, but the tasks already running keep a live context for up to
+// timeout to finish on their own. Those still running after that have their
+// context cancelled. Cancelling the pool's parent context, or WithTimeout
+// firing, still cancels the tasks at once.
+func (g *Pool[T]) WithGracefulShutdown(timeout time.Duration) *Pool[T] {
+	g.mustNotBeStarted()
+	g.shutdown = &gracefulShutdown{timeout: timeout, drain: make(chan struct{})}
+	return g
+}
+
+// ShutdownInfo reports, once the pool is done, how a graceful shutdown went:
+// graceful is false if the timeout fired and the pool had to cancel its
+// running tasks, and forcedCount is how many tasks were running at that point.
+// A pool that wasn't cancelled, or that has no graceful shutdown, reports
+// graceful.
+func (g *Pool[T]) ShutdownInfo() (graceful bool, forcedCount int) {
+	if g.shutdown == nil {
+		return true, 0
+	}
+	g.shutdown.mu.Lock()
+	defer g.shutdown.mu.Unlock()
+	return !g.shutdown.forced, g.shutdown.running
+}
+
+// stopping reports whether the pool should launch no more tasks.
+func (g *Pool[T]) stopping() bool {
+	return g.ctx.Err() != nil || (g.shutdown != nil && g.shutdown.draining.Load())
+}
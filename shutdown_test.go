@@ -0,0 +1,103 @@
+package gogo
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGracefulShutdown(t *testing.T) {
+	Convey("Given tasks that finish within the timeout, the shutdown should be graceful", t, func() {
+		var launched, cancelledEarly int32
+		pool := NewPoolContext(context.Background(), 3, 20, func(i int) func(ctx context.Context) (int, error) {
+			return func(ctx context.Context) (int, error) {
+				atomic.AddInt32(&launched, 1)
+				time.Sleep(30 * time.Millisecond)
+				if ctx.Err() != nil {
+					atomic.AddInt32(&cancelledEarly, 1)
+				}
+				return i, nil
+			}
+		}).WithGracefulShutdown(time.Second)
+		feed := pool.Go()
+		time.Sleep(10 * time.Millisecond)
+		pool.Cancel()
+		count := 0
+		for res := range feed {
+			So(res.Error, ShouldBeNil)
+			count++
+		}
+		So(count, ShouldEqual, 3)
+		So(atomic.LoadInt32(&launched), ShouldEqual, 3)
+		So(atomic.LoadInt32(&cancelledEarly), ShouldEqual, 0)
+		graceful, forced := pool.ShutdownInfo()
+		So(graceful, ShouldBeTrue)
+		So(forced, ShouldEqual, 0)
+	})
+
+	Convey("Given tasks that outlast the timeout, ShutdownInfo should report them force-cancelled", t, func() {
+		pool := NewPoolContext(context.Background(), 3, 20, func(i int) func(ctx context.Context) (int, error) {
+			return func(ctx context.Context) (int, error) {
+				<-ctx.Done()
+				return 0, ctx.Err()
+			}
+		}).WithGracefulShutdown(20 * time.Millisecond)
+		feed := pool.Go()
+		time.Sleep(10 * time.Millisecond)
+		pool.Cancel()
+		count := 0
+		for range feed {
+			count++
+		}
+		So(count, ShouldEqual, 3)
+		graceful, forced := pool.ShutdownInfo()
+		So(graceful, ShouldBeFalse)
+		So(forced, ShouldEqual, 3)
+	})
+
+	Convey("Given a pool that was never cancelled, ShutdownInfo should report graceful", t, func() {
+		pool := NewPool(2, 4, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		}).WithGracefulShutdown(time.Second)
+		pool.Wait()
+		graceful, forced := pool.ShutdownInfo()
+		So(graceful, ShouldBeTrue)
+		So(forced, ShouldEqual, 0)
+	})
+
+	Convey("Given repeated cancels and a clean shutdown, the force cancel should never fire", t, func() {
+		pool := NewPool(3, 20, func(i int) func() (int, error) {
+			return func() (int, error) {
+				time.Sleep(5 * time.Millisecond)
+				return i, nil
+			}
+		}).WithGracefulShutdown(30 * time.Millisecond)
+		feed := pool.Go()
+		time.Sleep(time.Millisecond)
+		wg := &sync.WaitGroup{}
+		wg.Add(10)
+		for c := 0; c < 10; c++ {
+			go func() {
+				defer wg.Done()
+				pool.Cancel()
+			}()
+		}
+		wg.Wait()
+		for range feed {
+		}
+		time.Sleep(50 * time.Millisecond) // Past the timeout
+		So(pool.ctx.Err(), ShouldBeNil)
+		graceful, forced := pool.ShutdownInfo()
+		So(graceful, ShouldBeTrue)
+		So(forced, ShouldEqual, 0)
+		pool.Cancel() // After the pool is done, still nothing to fire
+		time.Sleep(50 * time.Millisecond)
+		So(pool.ctx.Err(), ShouldBeNil)
+	})
+}
@@ -0,0 +1,34 @@
+package gogo
+
+import (
+	"time"
+)
+
+// WithStartInterval staggers the start of the pool: each worker waits d longer
+// than the one before it to launch its first task, so the first tasks start d
+// apart rather than all at once. Workers then launch the rest as they free up;
+// WithRateLimit is for pacing the whole run. Cancelling the pool cuts the waits
+// short, with WithGracefulShutdown too.
+func (g *Pool[T]) WithStartInterval(d time.Duration) *Pool[T] {
+	g.mustNotBeStarted()
+	g.startInterval = d
+	return g
+}
+
+// stagger holds worker w back until its turn to start.
+func (g *Pool[T]) stagger(w int) {
+	if w == 0 {
+		return
+	}
+	var drain <-chan struct{} // Nil, so never ready, without a graceful shutdown
+	if g.shutdown != nil {
+		drain = g.shutdown.drain
+	}
+	timer := time.NewTimer(time.Duration(w) * g.startInterval)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-g.ctx.Done():
+	case <-drain:
+	}
+}
@@ -0,0 +1,66 @@
+package gogo
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStartInterval(t *testing.T) {
+	Convey("Given a start interval, the first tasks should start that far apart", t, func() {
+		var mu sync.Mutex
+		var starts []time.Time
+		pool := NewPool(4, 4, func(i int) func() (int, error) {
+			return func() (int, error) {
+				mu.Lock()
+				starts = append(starts, time.Now())
+				mu.Unlock()
+				time.Sleep(200 * time.Millisecond) // Keep each worker to one task
+				return i, nil
+			}
+		}).WithStartInterval(30 * time.Millisecond)
+		begin := time.Now()
+		pool.Wait()
+		So(starts, ShouldHaveLength, 4)
+		for k, start := range starts {
+			So(start.Sub(begin), ShouldBeGreaterThanOrEqualTo, time.Duration(k)*30*time.Millisecond)
+		}
+	})
+
+	Convey("Given a cancel during the waits, the pool should finish without waiting them out", t, func() {
+		pool := NewPool(10, 10, func(i int) func() (int, error) {
+			return func() (int, error) {
+				time.Sleep(50 * time.Millisecond)
+				return i, nil
+			}
+		}).WithStartInterval(time.Hour)
+		time.AfterFunc(20*time.Millisecond, pool.Cancel)
+		start := time.Now()
+		count := 0
+		for range pool.Go() {
+			count++
+		}
+		So(time.Since(start), ShouldBeLessThan, time.Second)
+		// Only worker 0 got to launch a task before the cancel
+		So(count, ShouldEqual, 1)
+	})
+
+	Convey("Given a graceful shutdown during the waits, the pool should finish without forcing anything", t, func() {
+		pool := NewPool(4, 4, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		}).WithStartInterval(time.Hour).WithGracefulShutdown(300 * time.Millisecond)
+		pool.Go()
+		time.Sleep(20 * time.Millisecond)
+		start := time.Now()
+		pool.Cancel()
+		pool.Wait()
+		So(time.Since(start), ShouldBeLessThan, 100*time.Millisecond)
+		graceful, forced := pool.ShutdownInfo()
+		So(graceful, ShouldBeTrue)
+		So(forced, ShouldEqual, 0)
+	})
+}
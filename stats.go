@@ -0,0 +1,49 @@
+package gogo
+
+import (
+	"sync/atomic"
+)
+
+// PoolStats is a snapshot of a pool's progress.
+type PoolStats struct {
+	Submitted int // Tasks handed to a worker
+	Running   int // Tasks running right now
+	Completed int // Tasks that finished, whether or not they delivered a result
+	Succeeded int // Completed tasks that delivered a result
+	Failed    int // Completed tasks that delivered an error
+}
+
+// poolCounters backs Stats. Workers update it with a few atomic adds per
+// task, and the rest of the stats are derived when they are read.
+type poolCounters struct {
+	submitted atomic.Int64
+	started   atomic.Int64
+	succeeded atomic.Int64
+	failed    atomic.Int64
+	finished  atomic.Int64 // Started tasks that delivered nothing
+}
+
+// Stats returns the pool's counters as they are at the time of the call. Each
+// counter is read atomically, though not all of them at the same instant.
+func (g *Pool[T]) Stats() PoolStats {
+	// Read the outcomes before started, so Running can't go negative
+	succeeded := int(g.stats.succeeded.Load())
+	failed := int(g.stats.failed.Load())
+	finished := int(g.stats.finished.Load())
+	started := int(g.stats.started.Load())
+	return PoolStats{
+		Submitted: int(g.stats.submitted.Load()),
+		Running:   started - succeeded - failed - finished,
+		Completed: succeeded + failed + finished,
+		Succeeded: succeeded,
+		Failed:    failed,
+	}
+}
+
+// Completed returns how many tasks have run to completion, successfully or
+// not. Once the pool has finished, any of its size short of that were never
+// launched, because the pool was cancelled or, with WithCompletedSet, because
+// an earlier run completed them.
+func (g *Pool[T]) Completed() int {
+	return g.Stats().Completed
+}
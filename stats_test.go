@@ -0,0 +1,64 @@
+package gogo
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestStats(t *testing.T) {
+	Convey("Given a running pool, Stats should track its tasks", t, func() {
+		release := make(chan struct{})
+		pool := NewPool(3, 10, func(i int) func() (int, error) {
+			return func() (int, error) {
+				<-release
+				if i%4 == 0 {
+					return 0, errors.New("failed")
+				}
+				return i, nil
+			}
+		})
+		So(pool.Stats(), ShouldResemble, PoolStats{})
+		feed := pool.Go()
+		time.Sleep(20 * time.Millisecond)
+		stats := pool.Stats()
+		So(stats.Running, ShouldEqual, 3)
+		So(stats.Submitted, ShouldEqual, 3)
+		So(stats.Completed, ShouldEqual, 0)
+
+		close(release)
+		for range feed {
+		}
+		So(pool.Stats(), ShouldResemble, PoolStats{
+			Submitted: 10,
+			Running:   0,
+			Completed: 10,
+			Succeeded: 7,
+			Failed:    3,
+		})
+	})
+
+	Convey("Given a task restarted after a panic, Stats should count it once", t, func() {
+		var panicked atomic.Bool
+		pool := NewPool(1, 3, func(i int) func() (int, error) {
+			return func() (int, error) {
+				if i == 1 && !panicked.Swap(true) {
+					panic("boom")
+				}
+				return i, nil
+			}
+		}).WithWorkerRestart(1)
+		pool.Wait()
+		So(pool.Stats(), ShouldResemble, PoolStats{
+			Submitted: 3,
+			Running:   0,
+			Completed: 3,
+			Succeeded: 3,
+			Failed:    0,
+		})
+		So(pool.Completed(), ShouldEqual, 3)
+	})
+}
@@ -0,0 +1,87 @@
+package gogo
+
+import (
+	"sync/atomic"
+)
+
+// localQueue is a worker's own share of a pool's task indices: every
+// stride-th index starting at first. Its owner takes indices from the front,
+// in order, while idle workers steal from the back, so owner and thieves only
+// meet on the last few indices. Both ends live in one word, head in the upper
+// half and tail in the lower, so either can be moved with a single CAS.
+type localQueue struct {
+	ends   atomic.Uint64
+	first  int
+	stride int
+	_      [40]byte // Keep each queue on its own cache line
+}
+
+const queueTailMask = 1<<32 - 1
+
+func (q *localQueue) init(first, stride, size int) {
+	q.first = first
+	q.stride = stride
+	q.ends.Store(uint64((size - first + stride - 1) / stride))
+}
+
+// pop takes the lowest index left in the queue.
+func (q *localQueue) pop() (int, bool) {
+	for {
+		ends := q.ends.Load()
+		head, tail := ends>>32, ends&queueTailMask
+		if head >= tail {
+			return 0, false
+		}
+		if q.ends.CompareAndSwap(ends, (head+1)<<32|tail) {
+			return q.first + int(head)*q.stride, true
+		}
+	}
+}
+
+// steal takes the highest index left in the queue.
+func (q *localQueue) steal() (int, bool) {
+	for {
+		ends := q.ends.Load()
+		head, tail := ends>>32, ends&queueTailMask
+		if head >= tail {
+			return 0, false
+		}
+		if q.ends.CompareAndSwap(ends, head<<32|(tail-1)) {
+			return q.first + int(tail-1)*q.stride, true
+		}
+	}
+}
+
+// workQueues splits size task indices between n workers, round robin, so that
+// together the workers start on the lowest indices first.
+type workQueues []localQueue
+
+func newWorkQueues(n, size int) workQueues {
+	queues := make(workQueues, n)
+	for w := range queues {
+		queues[w].init(w, n, size)
+	}
+	return queues
+}
+
+// next returns the next index for worker w: its own lowest, or failing that
+// one stolen from another worker. victim remembers where the last steal
+// succeeded, so a worker keeps stealing from the same queue rather than
+// rescanning the empty ones every time. next reports false once every queue is
+// empty, and since no index is ever added back, that worker is done.
+func (qs workQueues) next(w int, victim *int) (int, bool) {
+	if i, ok := qs[w].pop(); ok {
+		return i, true
+	}
+	for k := 0; k < len(qs); k++ {
+		v := (*victim + k) % len(qs)
+		if v == w {
+			continue
+		}
+		if i, ok := qs[v].steal(); ok {
+			*victim = v
+			return i, true
+		}
+	}
+	return 0, false
+}
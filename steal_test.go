@@ -0,0 +1,124 @@
+package gogo
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWorkStealing(t *testing.T) {
+	Convey("Given work queues, each index should be handed out exactly once", t, func() {
+		queues := newWorkQueues(3, 10)
+		var owned []int
+		for i, ok := queues[0].pop(); ok; i, ok = queues[0].pop() {
+			owned = append(owned, i)
+		}
+		So(owned, ShouldResemble, []int{0, 3, 6, 9})
+
+		victim := 0
+		i, ok := queues.next(0, &victim)
+		So(ok, ShouldBeTrue)
+		So(i, ShouldEqual, 7) // Stolen from the back of worker 1's queue
+		So(victim, ShouldEqual, 1)
+
+		seen := map[int]bool{0: true, 3: true, 6: true, 9: true, 7: true}
+		victim = 2
+		for i, ok := queues.next(2, &victim); ok; i, ok = queues.next(2, &victim) {
+			So(seen[i], ShouldBeFalse)
+			seen[i] = true
+		}
+		So(seen, ShouldHaveLength, 10)
+	})
+
+	Convey("Given many workers racing over the queues, every task should run exactly once", t, func() {
+		runs := make([]int32, 10007)
+		group := NewPool(7, len(runs), func(i int) func() (int, error) {
+			return func() (int, error) {
+				atomic.AddInt32(&runs[i], 1)
+				return i, nil
+			}
+		})
+		count := 0
+		for range group.Go() {
+			count++
+		}
+		So(count, ShouldEqual, len(runs))
+		for i := range runs {
+			So(runs[i], ShouldEqual, 1)
+		}
+	})
+
+	Convey("Given a worker stuck on a slow task, the others should steal the rest of its work", t, func() {
+		start := time.Now()
+		group := NewPool(2, 20, func(i int) func() (int, error) {
+			return func() (int, error) {
+				if i == 0 {
+					time.Sleep(100 * time.Millisecond)
+				} else {
+					time.Sleep(10 * time.Millisecond)
+				}
+				return i, nil
+			}
+		})
+		group.Wait()
+		// Without stealing the slow worker would still have 9 tasks of its own
+		// to run after the slow one
+		So(time.Since(start), ShouldBeLessThan, 160*time.Millisecond)
+	})
+}
+
+// benchmarkDispatch hands size indices to concurrency workers, either through
+// a shared channel or through work queues.
+func benchmarkDispatch(b *testing.B, concurrency int, size int, stealing bool) {
+	for n := 0; n < b.N; n++ {
+		var sum atomic.Int64
+		workers := &sync.WaitGroup{}
+		workers.Add(concurrency)
+		if stealing {
+			queues := newWorkQueues(concurrency, size)
+			for w := 0; w < concurrency; w++ {
+				go func() {
+					victim := w
+					for i, ok := queues.next(w, &victim); ok; i, ok = queues.next(w, &victim) {
+						sum.Add(int64(i))
+					}
+					workers.Done()
+				}()
+			}
+		} else {
+			tasks := make(chan int)
+			for w := 0; w < concurrency; w++ {
+				go func() {
+					for i := range tasks {
+						sum.Add(int64(i))
+					}
+					workers.Done()
+				}()
+			}
+			for i := 0; i < size; i++ {
+				tasks <- i
+			}
+			close(tasks)
+		}
+		workers.Wait()
+	}
+}
+
+func BenchmarkDispatchSharedChannel(b *testing.B) {
+	benchmarkDispatch(b, 8, 100000, false)
+}
+
+func BenchmarkDispatchWorkStealing(b *testing.B) {
+	benchmarkDispatch(b, 8, 100000, true)
+}
+
+func BenchmarkDispatchSharedChannelHighConcurrency(b *testing.B) {
+	benchmarkDispatch(b, 256, 100000, false)
+}
+
+func BenchmarkDispatchWorkStealingHighConcurrency(b *testing.B) {
+	benchmarkDispatch(b, 256, 100000, true)
+}
@@ -0,0 +1,70 @@
+package gogo
+
+import (
+	"context"
+)
+
+// TeePolicy decides what TeeWith does with an item when one of its outputs
+// has no room for it.
+type TeePolicy int
+
+const (
+	// TeeBlock waits for the slow output, holding back the others.
+	TeeBlock TeePolicy = iota
+	// TeeDrop skips the item for the slow output only.
+	TeeDrop
+)
+
+// Tee duplicates every item from in onto n unbuffered outputs. Each item is
+// delivered to every output before the next is read, so the slowest consumer
+// sets the pace; see TeeWith to decouple them.
+func Tee[T any](ctx context.Context, in <-chan Optional[T], n int) []<-chan Optional[T] {
+	return TeeWith(ctx, in, n, 0, TeeBlock)
+}
+
+// TeeWith is like Tee, but each output can buffer bufSize items and policy
+// decides what happens to an item for an output that is full. The outputs
+// close once in is closed, after delivering what they buffered, or once ctx
+// is done.
+func TeeWith[T any](ctx context.Context, in <-chan Optional[T], n int, bufSize int, policy TeePolicy) []<-chan Optional[T] {
+	outs := make([]chan Optional[T], n)
+	readOnly := make([]<-chan Optional[T], n)
+	for i := range outs {
+		outs[i] = make(chan Optional[T], bufSize)
+		readOnly[i] = outs[i]
+	}
+	go func() {
+		defer func() {
+			for _, out := range outs {
+				close(out)
+			}
+		}()
+		for {
+			var res Optional[T]
+			var ok bool
+			select {
+			case res, ok = <-in:
+				if !ok {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+			for _, out := range outs {
+				if policy == TeeDrop {
+					select {
+					case out <- res:
+					default:
+					}
+					continue
+				}
+				select {
+				case out <- res:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return readOnly
+}
@@ -0,0 +1,73 @@
+package gogo
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTee(t *testing.T) {
+	squaresFeed := func(size int) chan Optional[int] {
+		return NewPool(2, size, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i * i, nil
+			}
+		}).Go()
+	}
+
+	Convey("Given a teed feed, every output should receive every item", t, func() {
+		outs := Tee(context.Background(), squaresFeed(20), 2)
+		sums := make([]int, len(outs))
+		wg := &sync.WaitGroup{}
+		wg.Add(len(outs))
+		for i, out := range outs {
+			go func() {
+				defer wg.Done()
+				for res := range out {
+					sums[i] += res.Result
+				}
+			}()
+		}
+		wg.Wait()
+		So(sums, ShouldResemble, []int{2470, 2470})
+	})
+
+	Convey("Given a drop policy, a slow output should not hold back a fast one", t, func() {
+		outs := TeeWith(context.Background(), squaresFeed(100), 2, 4, TeeDrop)
+		fast := 0
+		for range outs[0] {
+			fast++
+		}
+		slow := 0
+		for range outs[1] {
+			slow++
+		}
+		// Reading outs[0] to the end shows the unread outs[1] didn't block it
+		So(fast, ShouldBeGreaterThanOrEqualTo, slow)
+		So(slow, ShouldEqual, 4)
+	})
+
+	Convey("Given a buffered output, its items should still be readable after the input closes", t, func() {
+		outs := TeeWith(context.Background(), squaresFeed(5), 1, 5, TeeBlock)
+		time.Sleep(20 * time.Millisecond)
+		count := 0
+		for range outs[0] {
+			count++
+		}
+		So(count, ShouldEqual, 5)
+	})
+
+	Convey("Given a cancelled context, the outputs should close", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		in := make(chan Optional[int])
+		outs := Tee(ctx, in, 3)
+		cancel()
+		for _, out := range outs {
+			_, ok := <-out
+			So(ok, ShouldBeFalse)
+		}
+	})
+}
@@ -0,0 +1,46 @@
+package gogo
+
+import (
+	"context"
+	"time"
+)
+
+type throttle struct {
+	shouldPause  func() bool
+	pollInterval time.Duration
+}
+
+// wait returns once shouldPause reports false, checking again every
+// pollInterval, or once ctx is done.
+func (t *throttle) wait(ctx context.Context) {
+	if !t.shouldPause() {
+		return
+	}
+	ticker := time.NewTicker(t.pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if !t.shouldPause() {
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Throttle holds back each task, before it is launched, for as long as
+// shouldPause reports true, checking it again every pollInterval. It suits
+// backpressure from outside the pool, like a full downstream queue. Tasks
+// already running are left alone, and cancelling the pool ends the wait.
+// shouldPause is called from the workers, so it must be safe for concurrent
+// use. A pollInterval of zero or less checks every millisecond.
+func (g *Pool[T]) Throttle(shouldPause func() bool, pollInterval time.Duration) *Pool[T] {
+	g.mustNotBeStarted()
+	if pollInterval <= 0 {
+		pollInterval = time.Millisecond
+	}
+	g.throttle = &throttle{shouldPause: shouldPause, pollInterval: pollInterval}
+	return g
+}
@@ -0,0 +1,64 @@
+package gogo
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestThrottle(t *testing.T) {
+	Convey("Given a throttled pool, no task should launch while it is paused", t, func() {
+		var paused atomic.Bool
+		paused.Store(true)
+		var launched atomic.Int32
+		pool := NewPool(2, 6, func(i int) func() (int, error) {
+			return func() (int, error) {
+				launched.Add(1)
+				return i, nil
+			}
+		}).Throttle(paused.Load, 5*time.Millisecond)
+		feed := pool.Go()
+		time.Sleep(30 * time.Millisecond)
+		So(launched.Load(), ShouldEqual, 0)
+
+		paused.Store(false)
+		count := 0
+		for range feed {
+			count++
+		}
+		So(count, ShouldEqual, 6)
+	})
+
+	Convey("Given a pool cancelled while paused, it should finish without launching anything", t, func() {
+		var launched atomic.Int32
+		pool := NewPool(2, 6, func(i int) func() (int, error) {
+			return func() (int, error) {
+				launched.Add(1)
+				return i, nil
+			}
+		}).Throttle(func() bool { return true }, time.Hour)
+		time.AfterFunc(20*time.Millisecond, pool.Cancel)
+		start := time.Now()
+		pool.Wait()
+		So(time.Since(start), ShouldBeLessThan, time.Second)
+		So(launched.Load(), ShouldEqual, 0)
+	})
+
+	Convey("Given a poll interval of zero, the throttle should still poll rather than panic", t, func() {
+		var paused atomic.Bool
+		paused.Store(true)
+		time.AfterFunc(10*time.Millisecond, func() { paused.Store(false) })
+		pool := NewPool(1, 3, func(i int) func() (int, error) {
+			return func() (int, error) {
+				return i, nil
+			}
+		}).Throttle(paused.Load, 0)
+		count := 0
+		for range pool.Go() {
+			count++
+		}
+		So(count, ShouldEqual, 3)
+	})
+}
@@ -0,0 +1,29 @@
+package gogo
+
+import (
+	"context"
+)
+
+// NewWeightedPool is like NewPoolContext, but rather than a fixed number of
+// tasks, it runs as many at once as fit in totalWeight. fn returns each task's
+// weight along with the task. A task heavier than totalWeight still runs, on
+// its own, so a totalWeight of zero or less runs every task on its own.
+func NewWeightedPool[T any](ctx context.Context, totalWeight int64, size int, fn func(i int) (int64, func(ctx context.Context) (T, error))) *Pool[T] {
+	totalWeight = max(totalWeight, 1)
+	sem := newWeightedSemaphore(totalWeight)
+	concurrency := size
+	if int64(concurrency) > totalWeight {
+		concurrency = int(totalWeight)
+	}
+	return NewPoolContext(ctx, concurrency, size, func(i int) func(ctx context.Context) (T, error) {
+		weight, task := fn(i)
+		return func(ctx context.Context) (T, error) {
+			if !sem.acquire(ctx, weight) {
+				var zero T
+				return zero, ctx.Err()
+			}
+			defer sem.release(weight)
+			return task(ctx)
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package gogo
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWeightedPool(t *testing.T) {
+	Convey("Given weighted tasks, the weight running at once should stay within the budget", t, func() {
+		weights := []int64{1, 3, 2, 2, 1, 1, 4, 1, 2, 3, 8, 1, 1}
+		var mu sync.Mutex
+		var inFlight, peak int64
+		var running int
+		heavyRanAlone := true
+		pool := NewWeightedPool(context.Background(), 4, len(weights), func(i int) (int64, func(ctx context.Context) (int, error)) {
+			return weights[i], func(ctx context.Context) (int, error) {
+				mu.Lock()
+				inFlight += weights[i]
+				running++
+				if weights[i] > 4 && running > 1 {
+					heavyRanAlone = false
+				}
+				if weights[i] <= 4 && inFlight > peak {
+					peak = inFlight
+				}
+				mu.Unlock()
+				time.Sleep(5 * time.Millisecond)
+				mu.Lock()
+				inFlight -= weights[i]
+				running--
+				mu.Unlock()
+				return i, nil
+			}
+		})
+		count := 0
+		for res := range pool.Go() {
+			So(res.Error, ShouldBeNil)
+			count++
+		}
+		So(count, ShouldEqual, len(weights))
+		So(peak, ShouldBeLessThanOrEqualTo, 4)
+		So(peak, ShouldBeGreaterThan, 1)
+		So(heavyRanAlone, ShouldBeTrue)
+	})
+
+	Convey("Given a cancelled context, tasks waiting for weight should give up", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		release := make(chan struct{})
+		pool := NewWeightedPool(ctx, 2, 3, func(i int) (int64, func(ctx context.Context) (int, error)) {
+			return 2, func(ctx context.Context) (int, error) {
+				<-release
+				return i, nil
+			}
+		})
+		feed := pool.Go()
+		time.Sleep(20 * time.Millisecond)
+		cancel()
+		close(release)
+		var errs int
+		for res := range feed {
+			if res.Error != nil {
+				So(res.Error, ShouldWrap, context.Canceled)
+				errs++
+			}
+		}
+		So(errs, ShouldBeGreaterThan, 0)
+	})
+
+	Convey("Given a budget of zero or less, every task should still run, one at a time", t, func() {
+		for _, total := range []int64{0, -3} {
+			var running, peak atomic.Int32
+			pool := NewWeightedPool(context.Background(), total, 4, func(i int) (int64, func(ctx context.Context) (int, error)) {
+				return 1, func(ctx context.Context) (int, error) {
+					n := running.Add(1)
+					if n > peak.Load() {
+						peak.Store(n)
+					}
+					time.Sleep(5 * time.Millisecond)
+					running.Add(-1)
+					return i, nil
+				}
+			})
+			results, err := pool.Results()
+			So(err, ShouldBeNil)
+			So(results, ShouldResemble, []int{0, 1, 2, 3})
+			So(peak.Load(), ShouldEqual, 1)
+		}
+	})
+}
@@ -0,0 +1,76 @@
+package gogo
+
+import (
+	"context"
+)
+
+// Pair holds the results of two Procs that were awaited together.
+type Pair[A any, B any] struct {
+	First  A
+	Second B
+}
+
+// Zip returns a Proc that resolves to the results of both a and b. Both are
+// awaited concurrently, so it resolves as soon as the slower one finishes, or
+// as soon as either one fails, with that error. If ctx is done first the Proc
+// resolves to ctx.Err().
+func Zip[A any, B any](ctx context.Context, a *Proc[A], b *Proc[B]) *Proc[Pair[A, B]] {
+	return Go(func() (Pair[A, B], error) {
+		var pair Pair[A, B]
+		errs := make(chan error, 2)
+		go func() {
+			res, err := a.Result()
+			pair.First = res
+			errs <- err
+		}()
+		go func() {
+			res, err := b.Result()
+			pair.Second = res
+			errs <- err
+		}()
+		for i := 0; i < 2; i++ {
+			select {
+			case err := <-errs:
+				if err != nil {
+					return Pair[A, B]{}, err
+				}
+			case <-ctx.Done():
+				return Pair[A, B]{}, ctx.Err()
+			}
+		}
+		return pair, nil
+	})
+}
+
+// Apply returns a Proc that awaits a function from pf and a value from pt,
+// concurrently, and resolves to the function applied to the value. If either
+// fails, or ctx is done first, it resolves to that error instead.
+func Apply[T any, U any](ctx context.Context, pf *Proc[func(T) U], pt *Proc[T]) *Proc[U] {
+	both := Zip(ctx, pf, pt)
+	return Go(func() (U, error) {
+		pair, err := both.Result()
+		if err != nil {
+			var zero U
+			return zero, err
+		}
+		return pair.First(pair.Second), nil
+	})
+}
+
+// MapTo returns a Proc that resolves to f applied to p's result, under ctx.
+// If p fails its error is passed through and f is not called, and if ctx is
+// done before p resolves, it fails with ctx's error. A nil ctx falls back to
+// p's context.
+func MapTo[T any, U any](ctx context.Context, p *Proc[T], f func(T) (U, error)) *Proc[U] {
+	if ctx == nil {
+		ctx = p.Context()
+	}
+	return GoContext(ctx, func(ctx context.Context) (U, error) {
+		res, err := p.ResultContext(ctx)
+		if err != nil {
+			var zero U
+			return zero, err
+		}
+		return f(res)
+	})
+}
@@ -0,0 +1,139 @@
+package gogo
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestZip(t *testing.T) {
+	Convey("Given two Procs of different types, Zip should return both results", t, func() {
+		a := Go(func() (string, error) {
+			time.Sleep(200 * time.Millisecond)
+			return "user", nil
+		})
+		b := Go(func() ([]string, error) {
+			time.Sleep(200 * time.Millisecond)
+			return []string{"read", "write"}, nil
+		})
+		start := time.Now()
+		res, err := Zip(context.Background(), a, b).Result()
+		So(time.Since(start), ShouldBeLessThan, 350*time.Millisecond)
+		So(err, ShouldBeNil)
+		So(res.First, ShouldEqual, "user")
+		So(res.Second, ShouldResemble, []string{"read", "write"})
+	})
+
+	Convey("Given one failing Proc, Zip should return its error without waiting on the other", t, func() {
+		a := Go(func() (int, error) {
+			return 0, errors.New("boom")
+		})
+		b := Go(func() (int, error) {
+			time.Sleep(time.Second)
+			return 1, nil
+		})
+		start := time.Now()
+		_, err := Zip(context.Background(), a, b).Result()
+		So(err, ShouldNotBeNil)
+		So(time.Since(start), ShouldBeLessThan, 500*time.Millisecond)
+	})
+
+	Convey("Given a cancelled context, Zip should return the context error", t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		a := Go(func() (int, error) {
+			time.Sleep(time.Second)
+			return 1, nil
+		})
+		_, err := Zip(ctx, a, a).Result()
+		So(err, ShouldEqual, context.Canceled)
+	})
+}
+
+func TestApply(t *testing.T) {
+	Convey("Given a Proc of a function and a Proc of a value, Apply should apply one to the other", t, func() {
+		pf := Go(func() (func(int) string, error) {
+			return strconv.Itoa, nil
+		})
+		pt := Go(func() (int, error) {
+			return 42, nil
+		})
+		res, err := Apply(context.Background(), pf, pt).Result()
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, "42")
+	})
+
+	Convey("Given a failing function Proc, Apply should propagate its error", t, func() {
+		pf := Go(func() (func(int) string, error) {
+			return nil, errors.New("no function")
+		})
+		pt := Go(func() (int, error) {
+			return 42, nil
+		})
+		_, err := Apply(context.Background(), pf, pt).Result()
+		So(err.Error(), ShouldEqual, "no function")
+	})
+
+	Convey("Given a failing value Proc, Apply should propagate its error", t, func() {
+		pf := Go(func() (func(int) string, error) {
+			return strconv.Itoa, nil
+		})
+		pt := Go(func() (int, error) {
+			return 0, errors.New("no value")
+		})
+		_, err := Apply(context.Background(), pf, pt).Result()
+		So(err.Error(), ShouldEqual, "no value")
+	})
+}
+
+func TestMapTo(t *testing.T) {
+	Convey("Given a nil context, MapTo should fall back to the Proc's", t, func() {
+		type key string
+		ctx := context.WithValue(context.Background(), key("unit"), "ms")
+		p := GoContext(ctx, func(context.Context) (int, error) {
+			return 42, nil
+		})
+		mapped := MapTo(nil, p, func(v int) (string, error) {
+			return strconv.Itoa(v), nil
+		})
+		So(mapped.Context().Value(key("unit")), ShouldEqual, "ms")
+		res, err := mapped.Result()
+		So(err, ShouldBeNil)
+		So(res, ShouldEqual, "42")
+	})
+
+	Convey("Given a failed Proc, MapTo should pass its error through without calling f", t, func() {
+		errFailed := errors.New("failed")
+		called := false
+		_, err := MapTo(context.Background(), Fail[int](context.Background(), errFailed), func(v int) (string, error) {
+			called = true
+			return "", nil
+		}).Result()
+		So(err, ShouldEqual, errFailed)
+		So(called, ShouldBeFalse)
+	})
+
+	Convey("Given a nil context, Chain and ChainIn should run under the source pool's", t, func() {
+		type key string
+		source := func() *Pool[int] {
+			return NewPool(2, 3, func(i int) func() (int, error) {
+				return func() (int, error) {
+					return i, nil
+				}
+			}).WithValue(key("stage"), "source")
+		}
+		stage := func(ctx context.Context, v int) (string, error) {
+			return ctx.Value(key("stage")).(string), nil
+		}
+		for _, chained := range []*Pool[string]{Chain(nil, source(), 2, stage), ChainIn(nil, source(), 2, stage)} {
+			for res := range chained.Go() {
+				So(res.Error, ShouldBeNil)
+				So(res.Result, ShouldEqual, "source")
+			}
+		}
+	})
+}